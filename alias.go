@@ -0,0 +1,75 @@
+package goconfig
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+// OnDeprecatedKey sets fn to receive a warning whenever a value is bound from
+// a key declared with the `deprecated=<key>` tag option, together with its
+// replacement and the source that set it. By default warnings are logged
+// with slog.
+func (c *Config) OnDeprecatedKey(fn func(old, key, source string)) *Config {
+	c.onDeprecated = fn
+	return c
+}
+
+// applyAliases moves values set under the alias keys declared on target to
+// their field's key, warning about deprecated ones. setBy maps leaf keys to
+// the source that set them; secret keys are renamed along with their values.
+func (c *Config) applyAliases(merged map[string]any, setBy map[string]string, secrets []string, target any) ([]string, error) {
+	aliases := internal.Aliases(reflect.TypeOf(target), c.bindOptions())
+	if len(aliases) == 0 {
+		return secrets, nil
+	}
+	used, err := internal.ApplyAliases(merged, aliases, c.bindOptions())
+	if err != nil {
+		return nil, fmt.Errorf("resolving key aliases: %w", err)
+	}
+	for _, a := range used {
+		if a.Deprecated {
+			c.warnDeprecated(a.Old, a.Key, sourceOf(setBy, a.Old))
+		}
+		for i, s := range secrets {
+			if rest, ok := cutKeyPrefix(s, a.Old); ok {
+				secrets[i] = a.Key + rest
+			}
+		}
+	}
+	return secrets, nil
+}
+
+func (c *Config) warnDeprecated(old, key, source string) {
+	if c.onDeprecated != nil {
+		c.onDeprecated(old, key, source)
+		return
+	}
+	slog.Warn("deprecated configuration key", "key", old, "replacement", key, "source", source)
+}
+
+// sourceOf returns the source that set key or, for a section, one of its keys.
+func sourceOf(setBy map[string]string, key string) string {
+	for k, src := range setBy {
+		if _, ok := cutKeyPrefix(k, key); ok {
+			return src
+		}
+	}
+	return "unknown source"
+}
+
+// cutKeyPrefix reports whether key is prefix or lies below it, ignoring case,
+// and returns the remainder, e.g. ".port" for "db.port" and "db".
+func cutKeyPrefix(key, prefix string) (string, bool) {
+	if len(key) < len(prefix) || !strings.EqualFold(key[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := key[len(prefix):]
+	if rest != "" && rest[0] != '.' {
+		return "", false
+	}
+	return rest, true
+}
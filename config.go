@@ -2,6 +2,10 @@ package goconfig
 
 import (
 	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/shkmv/goconfig/internal"
 	"github.com/shkmv/goconfig/sources"
@@ -9,9 +13,43 @@ import (
 
 // Config represents a configuration object.
 type Config struct {
-	sources []sources.Source
+	sources      []sources.Source
+	interpolate  bool
+	resolvers    *internal.Resolvers
+	decryptKey   func() ([]byte, error)
+	profile      string
+	profileEnv   string
+	strategies   map[string]MergeStrategy
+	lenient      map[int]bool
+	strict       bool
+	zeroNull     bool
+	onUnknown    func(key, source string)
+	keyCase      KeyCase
+	naming       NamingStrategy
+	tagNames     []string
+	onDeprecated func(old, key, source string)
+
+	// mu guards secrets, the keys of secret values per bound target type.
+	mu      sync.Mutex
+	secrets map[reflect.Type][]string
+}
+
+// mergeOptionsSetter is implemented by sources that merge several files,
+// such as ConfDirSource, so the target's merge strategies apply between them.
+type mergeOptionsSetter interface {
+	SetMergeOptions(opts internal.MergeOptions)
 }
 
+// layer is a source scheduled for loading.
+type layer struct {
+	src     sources.Source
+	lenient bool
+}
+
+// ResolverFunc resolves the reference part of a secret URI, e.g. "show db"
+// for `exec://show db`, to the secret value.
+type ResolverFunc func(ref string) (string, error)
+
 func New() *Config {
 	return &Config{}
 }
@@ -34,19 +72,297 @@ func (c *Config) FromDotEnv(path string) *Config {
     return c
 }
 
+// FromAgeFile loads configuration from an age-encrypted file, decrypting it
+// with the identities stored in identityFile. All loaded values are masked by
+// c.MaskedMap and c.MaskedJSON.
+func (c *Config) FromAgeFile(path, identityFile string) *Config {
+	c.sources = append(c.sources, sources.NewAgeFileSource(path).WithIdentityFile(identityFile))
+	return c
+}
+
+// FromDir loads configuration from a directory of files, one key per file,
+// such as a mounted Kubernetes ConfigMap or Secret.
+func (c *Config) FromDir(path string) *Config {
+	c.sources = append(c.sources, sources.NewDirSource(path))
+	return c
+}
+
+// FromCredentials loads systemd credentials from $CREDENTIALS_DIRECTORY.
+// All loaded values are masked by c.MaskedMap and c.MaskedJSON.
+func (c *Config) FromCredentials() *Config {
+	c.sources = append(c.sources, sources.NewCredentialsSource())
+	return c
+}
+
+// FromConfDir loads and merges, in lexical order, all configuration
+// fragments in a conf.d style directory.
+func (c *Config) FromConfDir(dir string) *Config {
+	c.sources = append(c.sources, sources.NewConfDirSource(dir))
+	return c
+}
+
+// FromDiscovery loads the first `<name>.yaml|yml|json` found in the
+// conventional directories for app, see sources.DefaultSearchPaths.
+// UsedFiles reports which file was loaded.
+func (c *Config) FromDiscovery(app, name string) *Config {
+	c.sources = append(c.sources, sources.NewDiscoverySource(name, sources.DefaultSearchPaths(app)...))
+	return c
+}
+
+// FromDiscoveryAll is like FromDiscovery but loads every file found and merges
+// them so that earlier directories override later ones (system < user < local).
+func (c *Config) FromDiscoveryAll(app, name string) *Config {
+	c.sources = append(c.sources, sources.NewDiscoverySource(name, sources.DefaultSearchPaths(app)...).MergeAll())
+	return c
+}
+
+// UsedFiles returns the files the last Bind loaded through sources that pick
+// files themselves, such as FromDiscovery and FromConfDir, in merge order.
+func (c *Config) UsedFiles() []string {
+	var out []string
+	for _, src := range c.sources {
+		if u, ok := src.(interface{ Used() []string }); ok {
+			out = append(out, u.Used()...)
+		}
+	}
+	return out
+}
+
+// FromSource loads configuration from a custom or preconfigured source.
+func (c *Config) FromSource(src sources.Source) *Config {
+	c.sources = append(c.sources, src)
+	return c
+}
+
+// Interpolate enables resolution of `${key}`, `${env:VAR}` and
+// `${key:-fallback}` references in values after all sources are merged.
+func (c *Config) Interpolate() *Config {
+	c.interpolate = true
+	return c
+}
+
+// ResolveSecrets enables resolution of secret references such as
+// `secretref://file/run/secrets/db` or `secretref://env/DB_PASS` before
+// binding. Resolved values are masked by c.MaskedMap and c.MaskedJSON.
+func (c *Config) ResolveSecrets() *Config {
+	if c.resolvers == nil {
+		c.resolvers = internal.NewResolvers()
+	}
+	return c
+}
+
+// Resolver registers fn for secret references with the given URI scheme and
+// enables secret resolution.
+func (c *Config) Resolver(scheme string, fn ResolverFunc) *Config {
+	c.ResolveSecrets()
+	c.resolvers.Register(scheme, internal.ResolveFunc(fn))
+	return c
+}
+
+// Decrypt enables decryption of ENC[...] envelopes in loaded values using key.
+// Decrypted values are masked by c.MaskedMap and c.MaskedJSON.
+func (c *Config) Decrypt(key []byte) *Config {
+	c.decryptKey = func() ([]byte, error) { return key, nil }
+	return c
+}
+
+// DecryptWithKeyFile enables envelope decryption with a base64 or hex encoded
+// key read from path when binding.
+func (c *Config) DecryptWithKeyFile(path string) *Config {
+	c.decryptKey = keyFromFile(path)
+	return c
+}
+
+// DecryptWithKeyEnv enables envelope decryption with a base64 or hex encoded
+// key read from the environment variable name when binding.
+func (c *Config) DecryptWithKeyEnv(name string) *Config {
+	c.decryptKey = keyFromEnv(name)
+	return c
+}
+
+// SetProfile activates profile, layering `<name>.<profile>.<ext>` and
+// `.env.<profile>` overlays over every file and .env source when present.
+func (c *Config) SetProfile(profile string) *Config {
+	c.profile = profile
+	return c
+}
+
+// ProfileFromEnv selects the active profile from the environment variable
+// name, e.g. APP_PROFILE, unless a profile was set explicitly.
+func (c *Config) ProfileFromEnv(name string) *Config {
+	c.profileEnv = name
+	return c
+}
+
+// Profile returns the active profile, or "" if none is selected.
+func (c *Config) Profile() string {
+	if c.profile != "" {
+		return c.profile
+	}
+	if c.profileEnv != "" {
+		return os.Getenv(c.profileEnv)
+	}
+	return ""
+}
+
+// layeredSources returns the sources to load, with profile overlays inserted
+// directly after the source they override.
+func (c *Config) layeredSources() ([]layer, error) {
+	profile := c.Profile()
+	if profile != "" && (strings.ContainsAny(profile, `/\`) || strings.HasPrefix(profile, ".")) {
+		return nil, fmt.Errorf("invalid profile name %q", profile)
+	}
+
+	out := make([]layer, 0, len(c.sources))
+	for i, src := range c.sources {
+		out = append(out, layer{src: src, lenient: c.lenient[i]})
+		if profile == "" {
+			continue
+		}
+		if p, ok := src.(sources.ProfileSource); ok {
+			if overlay, ok := p.ProfileOverlay(profile); ok {
+				out = append(out, layer{src: overlay, lenient: c.lenient[i]})
+			}
+		}
+	}
+	return out, nil
+}
+
+// MergeStrategy sets how values for key (e.g. "server.hosts") from successive
+// sources combine. It takes precedence over a `merge` tag on the field.
+func (c *Config) MergeStrategy(key string, strategy MergeStrategy) *Config {
+	if c.strategies == nil {
+		c.strategies = make(map[string]MergeStrategy)
+	}
+	c.strategies[key] = strategy
+	return c
+}
+
+// ZeroNull makes an explicit null from any source zero non-nilable fields
+// such as strings and ints. By default they keep their current value, while
+// pointers, slices, maps and interfaces are always reset to nil.
+func (c *Config) ZeroNull() *Config {
+	c.zeroNull = true
+	return c
+}
+
+// mergeStrategies combines the `merge` tags of target with registered strategies.
+func (c *Config) mergeStrategies(target any) (internal.Strategies, error) {
+	strategies, err := internal.MergeStrategies(reflect.TypeOf(target), c.bindOptions())
+	if err != nil {
+		return nil, fmt.Errorf("reading merge strategies: %w", err)
+	}
+	for key, name := range c.strategies {
+		strategy, err := internal.ParseStrategy(string(name))
+		if err != nil {
+			return nil, fmt.Errorf("merge strategy for %s: %w", key, err)
+		}
+		strategies[key] = strategy
+	}
+	return strategies, nil
+}
+
 // Bind binds the configuration to a target struct.
 func (c *Config) Bind(target any) error {
+	if v := reflect.ValueOf(target); v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("binding configuration to target: target must be a non-nil pointer, got %T", target)
+	}
+
+	srcs, err := c.layeredSources()
+	if err != nil {
+		return err
+	}
+
+	var key []byte
+	if c.decryptKey != nil {
+		if key, err = c.decryptKey(); err != nil {
+			return fmt.Errorf("loading decryption key: %w", err)
+		}
+	}
+
+	strategies, err := c.mergeStrategies(target)
+	if err != nil {
+		return err
+	}
+
 	merged := make(map[string]any)
-	for _, src := range c.sources {
+	var secrets []string
+	origins := make(map[string]string)
+	setBy := make(map[string]string)
+	mergeOpts := internal.MergeOptions{
+		Strategies: strategies,
+		FoldCase:   c.keyCase != KeyCaseStrict,
+	}
+	for _, l := range srcs {
+		src := l.src
+		if m, ok := src.(mergeOptionsSetter); ok {
+			m.SetMergeOptions(mergeOpts)
+		}
 		data, err := src.Load()
 		if err != nil {
-			return fmt.Errorf("loading config from %T: %w", src, err)
+			return fmt.Errorf("loading config from %s: %w", describe(src), err)
+		}
+		if c.keyCase == KeyCaseLower {
+			data = internal.LowercaseKeys(data)
+		}
+		if key != nil {
+			decrypted, err := internal.DecryptEnvelopes(data, key)
+			if err != nil {
+				return fmt.Errorf("decrypting config from %s: %w", describe(src), err)
+			}
+			secrets = append(secrets, decrypted...)
+		}
+		if s, ok := src.(sources.SecretSource); ok && s.Secret() {
+			secrets = append(secrets, internal.LeafKeys(data)...)
+		}
+		for _, k := range internal.LeafKeys(data) {
+			setBy[k] = describe(src)
+			if !l.lenient {
+				origins[k] = describe(src)
+			}
+		}
+		merged = internal.MergeWith(merged, data, mergeOpts)
+	}
+
+	if c.interpolate {
+		interpolated, err := internal.Interpolate(merged, secrets)
+		if err != nil {
+			return fmt.Errorf("interpolating configuration: %w", err)
+		}
+		secrets = append(secrets, interpolated...)
+	}
+
+	if c.resolvers != nil {
+		resolved, err := c.resolvers.Resolve(merged)
+		if err != nil {
+			return fmt.Errorf("resolving secret references: %w", err)
 		}
-		merged = internal.Merge(merged, data)
+		secrets = append(secrets, resolved...)
 	}
 
-	if err := internal.Bind(merged, target); err != nil {
+	if secrets, err = c.applyAliases(merged, setBy, secrets, target); err != nil {
+		return err
+	}
+
+	if err := c.checkUnknownKeys(merged, origins, setBy, target); err != nil {
+		return err
+	}
+
+	// Secrets are recorded first so that MaskedMap masks them even for a
+	// partially bound target.
+	c.recordSecrets(target, secrets)
+	opts := c.bindOptions()
+	opts.Secrets = secrets
+	if err := internal.BindWithOptions(merged, target, opts); err != nil {
 		return fmt.Errorf("binding configuration to target: %w", err)
 	}
 	return nil
 }
+
+// describe names a source for error messages.
+func describe(src sources.Source) string {
+	if s, ok := src.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", src)
+}
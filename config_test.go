@@ -1,11 +1,15 @@
 package goconfig
 
 import (
+    "bytes"
+    "errors"
     "os"
     "path/filepath"
+    "reflect"
     "testing"
     "strings"
 
+    "filippo.io/age"
     "github.com/shkmv/goconfig/sources"
 )
 
@@ -558,3 +562,908 @@ func TestRequiredFieldsPresent(t *testing.T) {
     os.Unsetenv("APP_DB_HOST")
     os.Unsetenv("APP_PORT")
 }
+
+func TestCredentialsAreMasked(t *testing.T) {
+	type CredCfg struct {
+		DB struct {
+			Host string `config:"host"`
+			Pass string `config:"pass"`
+		} `config:"db"`
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.pass"), []byte("hunter2\n"), 0600); err != nil {
+		t.Fatalf("Failed to write credential: %v", err)
+	}
+	t.Setenv(sources.CredentialsDirectoryEnv, dir)
+
+	var cfg CredCfg
+	c := New().FromCredentials()
+	if err := c.Bind(&cfg); err != nil {
+		t.Fatalf("Failed to load config from credentials: %v", err)
+	}
+	if cfg.DB.Pass != "hunter2" {
+		t.Errorf("Expected DB.Pass to be 'hunter2', got '%s'", cfg.DB.Pass)
+	}
+
+	masked, err := c.MaskedJSON(cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	if strings.Contains(masked, "hunter2") {
+		t.Errorf("MaskedJSON leaked credential: %s", masked)
+	}
+
+	// Credentials bound into free-form fields mask the whole field.
+	type FreeCfg struct {
+		Labels map[string]string `config:"labels"`
+		Plugin any               `config:"plugin"`
+	}
+	for name, value := range map[string]string{"labels.token": "tok3n", "plugin.apikey": "k3y"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0600); err != nil {
+			t.Fatalf("Failed to write credential: %v", err)
+		}
+	}
+	var free FreeCfg
+	fc := New().FromCredentials()
+	if err := fc.Bind(&free); err != nil {
+		t.Fatalf("Failed to load config from credentials: %v", err)
+	}
+	if free.Labels["token"] != "tok3n" {
+		t.Errorf("Expected labels.token to be bound, got %v", free.Labels)
+	}
+	if masked, _ := fc.MaskedJSON(free); strings.Contains(masked, "tok3n") || strings.Contains(masked, "k3y") {
+		t.Errorf("MaskedJSON leaked credential in free-form field: %s", masked)
+	}
+
+	// Values interpolating a credential are masked too, and the credential
+	// itself is not interpolated.
+	udir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(udir, "db.pass"), []byte("pa$${ss"), 0600); err != nil {
+		t.Fatalf("Failed to write credential: %v", err)
+	}
+	t.Setenv(sources.CredentialsDirectoryEnv, udir)
+	type URLCfg struct {
+		Pass string `config:"db.pass"`
+		URL  string `config:"url"`
+	}
+	t.Setenv("CREDURL_URL", "postgres://app:${db.pass}@h/db")
+	var withURL URLCfg
+	uc := New().FromCredentials().FromEnv("CREDURL_").Interpolate()
+	if err := uc.Bind(&withURL); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if withURL.URL != "postgres://app:pa$${ss@h/db" {
+		t.Errorf("Expected URL to embed the verbatim credential, got %q", withURL.URL)
+	}
+	if masked, _ := uc.MaskedJSON(withURL); strings.Contains(masked, "pa$$") {
+		t.Errorf("MaskedJSON leaked interpolated credential: %s", masked)
+	}
+	t.Setenv(sources.CredentialsDirectoryEnv, dir)
+
+	// Load users get the Config through LoadConfig.
+	loaded, lc, err := LoadConfig[CredCfg](WithCredentials())
+	if err != nil {
+		t.Fatalf("Failed to load config from credentials: %v", err)
+	}
+	if masked, _ := lc.MaskedJSON(loaded); strings.Contains(masked, "hunter2") {
+		t.Errorf("MaskedJSON leaked credential loaded with LoadConfig: %s", masked)
+	}
+
+	// Secret keys are recorded on the Config that bound the target and
+	// never leak into other Configs.
+	var plain CredCfg
+	t.Setenv("CREDPLAIN_DB_PASS", "visible")
+	other := New().FromEnv("CREDPLAIN_")
+	if err := other.Bind(&plain); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if masked, _ := other.MaskedJSON(plain); !strings.Contains(masked, "visible") {
+		t.Errorf("Expected value from a plain source to stay visible, got %s", masked)
+	}
+}
+
+func TestInterpolationAcrossSources(t *testing.T) {
+	type InterpCfg struct {
+		DB struct {
+			URL string `config:"url"`
+		} `config:"db"`
+		LogDir string `config:"log.dir"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	yamlContent := `
+db:
+  user: app
+  host: db.local
+  port: 5432
+  url: "postgres://${db.user}@${db.host}:${db.port}/app"
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	envPath := filepath.Join(tempDir, ".env")
+	if err := os.WriteFile(envPath, []byte("LOG_DIR=${HOME}/logs\n"), 0644); err != nil {
+		t.Fatalf("Failed to write dotenv file: %v", err)
+	}
+	t.Setenv("HOME", "/home/app")
+
+	cfg, err := Load[InterpCfg](WithFile(yamlPath), WithDotEnv(envPath), WithInterpolation())
+	if err != nil {
+		t.Fatalf("Failed to load config with interpolation: %v", err)
+	}
+	if cfg.DB.URL != "postgres://app@db.local:5432/app" {
+		t.Errorf("Expected DB.URL to be interpolated, got '%s'", cfg.DB.URL)
+	}
+	if cfg.LogDir != "/home/app/logs" {
+		t.Errorf("Expected LogDir to be '/home/app/logs', got '%s'", cfg.LogDir)
+	}
+}
+
+func TestSecretReferencesAreResolvedAndMasked(t *testing.T) {
+	type RefCfg struct {
+		DB struct {
+			Pass string `config:"pass"`
+		} `config:"db"`
+		Token string `config:"token"`
+		Key   string `config:"key"`
+		Host  string `config:"host"`
+	}
+
+	tempDir := t.TempDir()
+	secretPath := filepath.Join(tempDir, "db")
+	if err := os.WriteFile(secretPath, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	yamlContent := "db:\n  pass: \"secretref://file" + secretPath + "\"\ntoken: \"exec://pass show token\"\nkey: \"secretref://exec/pass show key\"\nhost: localhost\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+
+	var cfg RefCfg
+	c := New().FromFile(yamlPath).Resolver("exec", func(ref string) (string, error) {
+		return "resolved(" + ref + ")", nil
+	})
+	if err := c.Bind(&cfg); err != nil {
+		t.Fatalf("Failed to load config with secret references: %v", err)
+	}
+	if cfg.DB.Pass != "hunter2" {
+		t.Errorf("Expected DB.Pass to be 'hunter2', got '%s'", cfg.DB.Pass)
+	}
+	if cfg.Token != "resolved(pass show token)" {
+		t.Errorf("Expected Token to be resolved, got '%s'", cfg.Token)
+	}
+	if cfg.Key != "resolved(pass show key)" {
+		t.Errorf("Expected secretref Key to be resolved without a leading slash, got '%s'", cfg.Key)
+	}
+
+	masked, err := c.MaskedJSON(cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	if strings.Contains(masked, "hunter2") || strings.Contains(masked, "resolved(") {
+		t.Errorf("MaskedJSON leaked resolved secrets: %s", masked)
+	}
+	if !strings.Contains(masked, "localhost") {
+		t.Errorf("MaskedJSON missing non-secret fields: %s", masked)
+	}
+}
+
+func TestAgeFileValuesAreMasked(t *testing.T) {
+	type AgeCfg struct {
+		DB struct {
+			Pass string `config:"pass"`
+		} `config:"db"`
+		Port int `config:"port"`
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if _, err := w.Write([]byte("db:\n  pass: hunter2\nport: 3000\n")); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	tempDir := t.TempDir()
+	agePath := filepath.Join(tempDir, "config.yaml.age")
+	keyPath := filepath.Join(tempDir, "key.txt")
+	if err := os.WriteFile(agePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write age file: %v", err)
+	}
+	if err := os.WriteFile(keyPath, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write identity file: %v", err)
+	}
+
+	var cfg AgeCfg
+	c := New().FromAgeFile(agePath, keyPath)
+	if err := c.Bind(&cfg); err != nil {
+		t.Fatalf("Failed to load age file: %v", err)
+	}
+	if cfg.DB.Pass != "hunter2" || cfg.Port != 3000 {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+
+	masked, err := c.MaskedJSON(cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	if strings.Contains(masked, "hunter2") || strings.Contains(masked, "3000") {
+		t.Errorf("MaskedJSON leaked values from the age file: %s", masked)
+	}
+}
+
+func TestEncryptedValuesAreDecrypted(t *testing.T) {
+	type EncCfg struct {
+		DB struct {
+			Host string `config:"host"`
+			Pass string `config:"pass"`
+		} `config:"db"`
+	}
+
+	encodedKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	key, err := ParseKey(encodedKey)
+	if err != nil {
+		t.Fatalf("ParseKey failed: %v", err)
+	}
+	envelope, err := EncryptValue(key, "db.pass", "hunter2")
+	if err != nil {
+		t.Fatalf("EncryptValue failed: %v", err)
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	yamlContent := "db:\n  host: localhost\n  pass: " + envelope + "\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	keyPath := filepath.Join(tempDir, "config.key")
+	if err := os.WriteFile(keyPath, []byte(encodedKey+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	var cfg EncCfg
+	c := New().FromFile(yamlPath).DecryptWithKeyFile(keyPath)
+	if err := c.Bind(&cfg); err != nil {
+		t.Fatalf("Failed to load encrypted config: %v", err)
+	}
+	if cfg.DB.Pass != "hunter2" {
+		t.Errorf("Expected DB.Pass to be 'hunter2', got '%s'", cfg.DB.Pass)
+	}
+
+	masked, err := c.MaskedJSON(cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	if strings.Contains(masked, "hunter2") {
+		t.Errorf("MaskedJSON leaked decrypted value: %s", masked)
+	}
+
+	t.Setenv("GOCONFIG_TEST_KEY", encodedKey)
+	cfg, err = Load[EncCfg](WithFile(yamlPath), WithDecryptionKeyEnv("GOCONFIG_TEST_KEY"))
+	if err != nil {
+		t.Fatalf("Failed to load encrypted config with env key: %v", err)
+	}
+	if cfg.DB.Pass != "hunter2" {
+		t.Errorf("Expected DB.Pass to be 'hunter2', got '%s'", cfg.DB.Pass)
+	}
+}
+
+func TestProfileOverlays(t *testing.T) {
+	type ProfileCfg struct {
+		DB struct {
+			Host string `config:"host"`
+			Port int    `config:"port"`
+		} `config:"db"`
+		Port     int    `config:"port"`
+		LogLevel string `config:"log.level"`
+	}
+
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"config.yaml":            "db:\n  host: localhost\n  port: 5432\nport: 3000\n",
+		"config.production.yaml": "db:\n  host: db.prod\n",
+		".env":                   "LOG_LEVEL=debug\n",
+		".env.production":        "LOG_LEVEL=warn\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	envPath := filepath.Join(tempDir, ".env")
+
+	t.Setenv("GOCONFIG_TEST_PROFILE", "production")
+	c := New().FromFile(yamlPath).FromDotEnv(envPath).ProfileFromEnv("GOCONFIG_TEST_PROFILE")
+	if c.Profile() != "production" {
+		t.Errorf("Expected active profile 'production', got '%s'", c.Profile())
+	}
+
+	var cfg ProfileCfg
+	if err := c.Bind(&cfg); err != nil {
+		t.Fatalf("Failed to bind profiled config: %v", err)
+	}
+	if cfg.DB.Host != "db.prod" || cfg.DB.Port != 5432 || cfg.Port != 3000 {
+		t.Errorf("Unexpected values with production overlay: %+v", cfg)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("Expected LogLevel from .env.production to be 'warn', got '%s'", cfg.LogLevel)
+	}
+
+	// A profile without overlay files falls back to the base files.
+	cfg, err := Load[ProfileCfg](WithFile(yamlPath), WithDotEnv(envPath), WithProfile("staging"))
+	if err != nil {
+		t.Fatalf("Failed to load config with staging profile: %v", err)
+	}
+	if cfg.DB.Host != "localhost" || cfg.LogLevel != "debug" {
+		t.Errorf("Unexpected values without overlays: %+v", cfg)
+	}
+}
+
+func TestMergeStrategiesAcrossSources(t *testing.T) {
+	type MergeCfg struct {
+		Hosts   []string          `config:"hosts" merge:"append"`
+		Tags    []string          `config:"tags"`
+		Plugins []string          `config:"plugins"`
+		Labels  map[string]string `config:"labels" merge:"replace"`
+	}
+
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.yaml")
+	base := "hosts: [a, b]\ntags: [x, y]\nplugins: [p1]\nlabels:\n  team: core\n  tier: \"1\"\n"
+	overlayPath := filepath.Join(tempDir, "overlay.yaml")
+	overlay := "hosts: [c]\ntags: [y, z]\nplugins: [p2]\nlabels:\n  team: edge\n"
+	if err := os.WriteFile(basePath, []byte(base), 0644); err != nil {
+		t.Fatalf("Failed to write base file: %v", err)
+	}
+	if err := os.WriteFile(overlayPath, []byte(overlay), 0644); err != nil {
+		t.Fatalf("Failed to write overlay file: %v", err)
+	}
+
+	cfg, err := Load[MergeCfg](
+		WithFile(basePath),
+		WithFile(overlayPath),
+		WithMergeStrategy("tags", MergeUnion),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load config with merge strategies: %v", err)
+	}
+
+	if strings.Join(cfg.Hosts, ",") != "a,b,c" {
+		t.Errorf("Expected Hosts to be appended, got %v", cfg.Hosts)
+	}
+	if strings.Join(cfg.Tags, ",") != "x,y,z" {
+		t.Errorf("Expected Tags to be a set union, got %v", cfg.Tags)
+	}
+	if strings.Join(cfg.Plugins, ",") != "p2" {
+		t.Errorf("Expected Plugins to be replaced, got %v", cfg.Plugins)
+	}
+	if len(cfg.Labels) != 1 || cfg.Labels["team"] != "edge" {
+		t.Errorf("Expected Labels to be replaced as a whole, got %v", cfg.Labels)
+	}
+}
+
+func TestNullOverridesEarlierSources(t *testing.T) {
+	type NullCfg struct {
+		DB struct {
+			Host string `config:"host"`
+			Port int    `config:"port"`
+		} `config:"db"`
+		Hosts []string `config:"hosts"`
+	}
+
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.yaml")
+	overlayPath := filepath.Join(tempDir, "overlay.yaml")
+	if err := os.WriteFile(basePath, []byte("db:\n  host: base-host\n  port: 5432\nhosts: [a, b]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base file: %v", err)
+	}
+	if err := os.WriteFile(overlayPath, []byte("db:\n  host: null\nhosts: ~\n"), 0644); err != nil {
+		t.Fatalf("Failed to write overlay file: %v", err)
+	}
+
+	cfg := NullCfg{Hosts: []string{"default"}}
+	cfg.DB.Host = "default-host"
+	if err := New().FromFile(basePath).FromFile(overlayPath).Bind(&cfg); err != nil {
+		t.Fatalf("Failed to bind config with nulls: %v", err)
+	}
+
+	if cfg.DB.Host != "default-host" {
+		t.Errorf("Expected null to restore the default DB.Host, got '%s'", cfg.DB.Host)
+	}
+	if cfg.DB.Port != 5432 {
+		t.Errorf("Expected DB.Port to be 5432, got %d", cfg.DB.Port)
+	}
+	if cfg.Hosts != nil {
+		t.Errorf("Expected null to reset Hosts to nil, got %v", cfg.Hosts)
+	}
+
+	zeroed := NullCfg{Hosts: []string{"default"}}
+	zeroed.DB.Host = "default-host"
+	if err := New().FromFile(basePath).FromFile(overlayPath).ZeroNull().Bind(&zeroed); err != nil {
+		t.Fatalf("Failed to bind config with nulls: %v", err)
+	}
+	if zeroed.DB.Host != "" || zeroed.DB.Port != 5432 || zeroed.Hosts != nil {
+		t.Errorf("Expected null to zero DB.Host only, got %+v", zeroed)
+	}
+
+	// A later source still overrides an earlier null.
+	t.Setenv("NULLAPP_DB_HOST", "env-host")
+	loaded, err := Load[NullCfg](WithFile(basePath), WithFile(overlayPath), WithEnv("NULLAPP_"), WithZeroNull())
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if loaded.DB.Host != "env-host" || loaded.DB.Port != 5432 {
+		t.Errorf("Expected env to override null, got %+v", loaded)
+	}
+}
+
+func TestStrictModeRejectsUnknownKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte("db:\n  hots: typo\n  port: 5432\nport: 3000\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("STRICTAPP_DB_HOST", "env-host")
+	t.Setenv("STRICTAPP_UNRELATED", "x")
+
+	_, err := Load[TestConfig](WithFile(yamlPath), WithEnv("STRICTAPP_"), WithLenient(), WithStrict())
+	if err == nil {
+		t.Fatal("Expected error for unknown keys in strict mode, got nil")
+	}
+	if !strings.Contains(err.Error(), "db.hots (from file "+yamlPath+")") {
+		t.Errorf("Expected error to name db.hots and its source, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "unrelated") {
+		t.Errorf("Expected lenient env source to be exempt, got: %v", err)
+	}
+
+	var warned []string
+	cfg, err := Load[TestConfig](
+		WithFile(yamlPath),
+		WithEnv("STRICTAPP_"),
+		WithUnknownKeyHandler(func(key, source string) {
+			warned = append(warned, key+"@"+source)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected warnings instead of an error, got: %v", err)
+	}
+	if cfg.DB.Host != "env-host" || cfg.Port != 3000 {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+	expected := []string{"db.hots@file " + yamlPath, "unrelated@environment STRICTAPP_*"}
+	if strings.Join(warned, ";") != strings.Join(expected, ";") {
+		t.Errorf("Expected warnings %v, got %v", expected, warned)
+	}
+
+	// Keys spelled differently from the merged result are still checked
+	// when key case is folded.
+	upperPath := filepath.Join(tempDir, "upper.yaml")
+	if err := os.WriteFile(upperPath, []byte("DB:\n  Hots: x\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("RVX_DB_PORT", "5432")
+	for _, mode := range []KeyCase{KeyCaseInsensitive, KeyCaseLower} {
+		err := New().FromEnv("RVX_").Lenient().FromFile(upperPath).KeyCase(mode).Strict().Bind(&TestConfig{})
+		if err == nil || !strings.Contains(strings.ToLower(err.Error()), "db.hots (from file") {
+			t.Errorf("Expected db.hots to be rejected with key case %d, got: %v", mode, err)
+		}
+	}
+}
+
+func TestKeyCaseUnifiesSources(t *testing.T) {
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte("DB:\n  Host: yaml-host\n  Port: 5432\nPort: 3000\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("CASEAPP_DB_HOST", "env-host")
+
+	cfg, err := Load[TestConfig](WithFile(yamlPath), WithEnv("CASEAPP_"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.DB.Port != 0 {
+		t.Errorf("Expected strict key case to ignore 'DB.Port', got %d", cfg.DB.Port)
+	}
+
+	for _, mode := range []KeyCase{KeyCaseLower, KeyCaseInsensitive} {
+		cfg, err := Load[TestConfig](WithFile(yamlPath), WithEnv("CASEAPP_"), WithKeyCase(mode), WithStrict())
+		if err != nil {
+			t.Fatalf("Failed to load config with key case %d: %v", mode, err)
+		}
+		if cfg.DB.Host != "env-host" || cfg.DB.Port != 5432 || cfg.Port != 3000 {
+			t.Errorf("Unexpected values with key case %d: %+v", mode, cfg)
+		}
+	}
+}
+
+func TestNamingStrategyForUntaggedFields(t *testing.T) {
+	type Database struct {
+		Host     string
+		MaxConns int
+		Password string `secret:"true"`
+	}
+	type AppConfig struct {
+		AppName  string
+		Database Database
+		Internal string `config:"-"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	content := "app-name: demo\ninternal: leaked\ndatabase:\n  host: localhost\n  max-conns: 10\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("NAMINGAPP_DATABASE_PASSWORD", "hunter2")
+
+	cfg, err := Load[AppConfig](WithFile(yamlPath), WithNaming(KebabCase))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.AppName != "demo" || cfg.Database.Host != "localhost" || cfg.Database.MaxConns != 10 || cfg.Internal != "" {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+
+	var snake AppConfig
+	c := New().FromEnv("NAMINGAPP_").Naming(SnakeCase)
+	if err := c.Bind(&snake); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if snake.Database.Password != "hunter2" {
+		t.Errorf("Expected password from env, got %q", snake.Database.Password)
+	}
+	masked, err := c.MaskedMap(&snake)
+	if err != nil {
+		t.Fatalf("MaskedMap failed: %v", err)
+	}
+	if masked["database"].(map[string]any)["password"] != "***" {
+		t.Errorf("Expected masked password, got %v", masked)
+	}
+	if _, ok := masked["internal"]; ok {
+		t.Errorf("Expected excluded field to be absent, got %v", masked)
+	}
+
+	// Masking never depends on how an earlier Bind was configured.
+	plain, err := MaskedMap(&snake)
+	if err != nil {
+		t.Fatalf("MaskedMap failed: %v", err)
+	}
+	if _, ok := plain["database"]; ok {
+		t.Errorf("Expected untagged fields to be skipped without naming, got %v", plain)
+	}
+	kebab, err := MaskedMap(&snake, WithMaskNaming(KebabCase))
+	if err != nil {
+		t.Fatalf("MaskedMap failed: %v", err)
+	}
+	if kebab["app-name"] != "" || kebab["database"].(map[string]any)["max-conns"] != 0 {
+		t.Errorf("Expected kebab-case keys, got %v", kebab)
+	}
+}
+
+func TestEmbeddedStructsArePromoted(t *testing.T) {
+	type Base struct {
+		Name    string   `config:"name"`
+		Plugins []string `config:"plugins" merge:"append"`
+	}
+	type AppConfig struct {
+		Base
+		Port int `config:"port"`
+	}
+
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.yaml")
+	localPath := filepath.Join(tempDir, "local.yaml")
+	if err := os.WriteFile(basePath, []byte("name: demo\nplugins: [auth]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	if err := os.WriteFile(localPath, []byte("port: 8080\nplugins: [metrics]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+
+	cfg, err := Load[AppConfig](WithFile(basePath), WithFile(localPath), WithStrict())
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Name != "demo" || cfg.Port != 8080 || strings.Join(cfg.Plugins, ",") != "auth,metrics" {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+}
+
+func TestDiscoveryReportsUsedFiles(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+		Port int    `config:"port"`
+	}
+
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	userPath := filepath.Join(xdg, "goconfigtest", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(userPath), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(userPath, []byte("name: user\nport: 80\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	if err := os.WriteFile("config.yaml", []byte("port: 3000\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+
+	var first AppConfig
+	c := New().FromDiscovery("goconfigtest", "config")
+	if err := c.Bind(&first); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if first.Name != "" || first.Port != 3000 || !reflect.DeepEqual(c.UsedFiles(), []string{"config.yaml"}) {
+		t.Errorf("Expected only the local file, got %+v from %v", first, c.UsedFiles())
+	}
+
+	var all AppConfig
+	c = New().FromDiscoveryAll("goconfigtest", "config")
+	if err := c.Bind(&all); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if all.Name != "user" || all.Port != 3000 {
+		t.Errorf("Expected local file to override the user file, got %+v", all)
+	}
+	if want := []string{userPath, "config.yaml"}; !reflect.DeepEqual(c.UsedFiles(), want) {
+		t.Errorf("Expected used files %v, got %v", want, c.UsedFiles())
+	}
+}
+
+func TestMergeStrategiesApplyBetweenFragments(t *testing.T) {
+	type AppConfig struct {
+		Plugins []string `config:"plugins" merge:"append"`
+	}
+
+	confDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(confDir, "10-base.yaml"), []byte("plugins: [auth]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write fragment: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(confDir, "50-local.yaml"), []byte("plugins: [metrics]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write fragment: %v", err)
+	}
+
+	cfg, err := Load[AppConfig](WithConfDir(confDir))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if strings.Join(cfg.Plugins, ",") != "auth,metrics" {
+		t.Errorf("Expected fragments to be appended, got %v", cfg.Plugins)
+	}
+}
+
+func TestTagNamesReuseExistingTags(t *testing.T) {
+	type AppConfig struct {
+		Host string `yaml:"host,omitempty"`
+		Port int    `mapstructure:"port"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte("host: localhost\nport: 8080\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+
+	cfg, err := Load[AppConfig](WithFile(yamlPath))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Host != "" || cfg.Port != 0 {
+		t.Errorf("Expected only config tags by default, got %+v", cfg)
+	}
+
+	cfg, err = Load[AppConfig](WithFile(yamlPath), WithTagNames("config", "yaml", "mapstructure"), WithStrict())
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 8080 {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+}
+
+func TestDeprecatedKeyAliases(t *testing.T) {
+	type DB struct {
+		Host     string `config:"host,deprecated=hostname"`
+		Password string `config:"password,alias=pass"`
+	}
+	type AppConfig struct {
+		DB DB `config:"db"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte("db:\n  hostname: legacy-host\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("ALIASAPP_DB_PASS", "hunter2")
+
+	var warnings []string
+	var cfg AppConfig
+	err := New().FromFile(yamlPath).FromEnv("ALIASAPP_").Strict().
+		OnDeprecatedKey(func(old, key, source string) {
+			warnings = append(warnings, old+"->"+key+"@"+source)
+		}).Bind(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if cfg.DB.Host != "legacy-host" || cfg.DB.Password != "hunter2" {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+	expected := []string{"db.hostname->db.host@file " + yamlPath}
+	if strings.Join(warnings, ";") != strings.Join(expected, ";") {
+		t.Errorf("Expected warnings %v, got %v", expected, warnings)
+	}
+
+	t.Setenv("ALIASAPP_DB_HOST", "new-host")
+	_, err = Load[AppConfig](WithFile(yamlPath), WithEnv("ALIASAPP_"), WithDeprecationHandler(func(old, key, source string) {}))
+	if err == nil || !strings.Contains(err.Error(), "db.hostname") {
+		t.Errorf("Expected conflict error mentioning db.hostname, got %v", err)
+	}
+}
+
+func TestBindErrorsMaskSecretValues(t *testing.T) {
+	type AppConfig struct {
+		DB struct {
+			Port     int           `config:"port"`
+			Password int           `config:"password" secret:"true"`
+			PIN      Secret[int]   `config:"pin"`
+			Tokens   []Secret[int] `config:"tokens"`
+			Retries  int           `config:"retries"`
+			MaxBody  ByteSize      `config:"maxbody" secret:"true"`
+		} `config:"db"`
+	}
+
+	t.Setenv("MASKERR_DB_PASSWORD", "hunter2")
+	_, err := Load[AppConfig](WithEnv("MASKERR_"))
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected a FieldError, got %v", err)
+	}
+	if fe.Key != "db.password" || !fe.Secret || fe.Type.Kind() != reflect.Int {
+		t.Errorf("Unexpected FieldError %+v", fe)
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Secret value leaked: %v", err)
+	}
+
+	t.Setenv("MASKPIN_DB_PIN", "letmein")
+	if _, err := Load[AppConfig](WithEnv("MASKPIN_")); err == nil || strings.Contains(err.Error(), "letmein") {
+		t.Errorf("Expected masked error for Secret field, got %v", err)
+	}
+
+	// Parse errors that quote a transformed value must not leak either.
+	t.Setenv("MASKSIZE_DB_MAXBODY", "12hunter")
+	_, err = Load[AppConfig](WithEnv("MASKSIZE_"))
+	if err == nil || strings.Contains(err.Error(), "hunter") {
+		t.Errorf("Expected masked error for ByteSize, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "invalid value for goconfig.ByteSize") {
+		t.Errorf("Expected the type in the error, got %v", err)
+	}
+
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "tokens.yaml")
+	if err := os.WriteFile(yamlPath, []byte("db:\n  tokens: [1, hunter2]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	if _, err := Load[AppConfig](WithFile(yamlPath)); err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Expected masked error for Secret list, got %v", err)
+	}
+
+	dir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.retries"), []byte("s3cr3t"), 0600); err != nil {
+		t.Fatalf("Failed to write credential: %v", err)
+	}
+	t.Setenv(sources.CredentialsDirectoryEnv, dir)
+	if _, err := Load[AppConfig](WithCredentials()); err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Expected masked error for credential, got %v", err)
+	}
+}
+
+func TestMaskingModes(t *testing.T) {
+	RegisterMasker("len", func(v string) string { return strings.Repeat("*", len(v)) })
+	type AppConfig struct {
+		APIKey string `config:"apikey" secret:"last4"`
+		DSN    string `config:"dsn" secret:"url"`
+		PIN    string `config:"pin" secret:"len"`
+		Port   int    `config:"port" secret:"last4"`
+	}
+
+	t.Setenv("MASKMODE_APIKEY", "sk-live-1234567890abcdef")
+	t.Setenv("MASKMODE_DSN", "postgres://app:s3cr3t@db:5432/app")
+	t.Setenv("MASKMODE_PIN", "1234")
+	t.Setenv("MASKMODE_PORT", "s3cr3t")
+
+	_, err := Load[AppConfig](WithEnv("MASKMODE_"))
+	if err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Expected masked bind error, got %v", err)
+	}
+
+	t.Setenv("MASKMODE_PORT", "5432")
+	cfg, err := Load[AppConfig](WithEnv("MASKMODE_"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	masked, err := MaskedJSON(&cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	expected := `{"apikey":"***cdef","dsn":"postgres://app:***@db:5432/app","pin":"****","port":"***"}`
+	if masked != expected {
+		t.Errorf("Expected %s, got %s", expected, masked)
+	}
+}
+
+func TestMaskedJSONWithSecretDetection(t *testing.T) {
+	type AppConfig struct {
+		DBPassword string `config:"db_password"`
+		CacheKey   string `config:"cache_key"`
+		Host       string `config:"host"`
+	}
+	cfg := AppConfig{DBPassword: "hunter2", CacheKey: "users:", Host: "localhost"}
+
+	masked, err := MaskedJSON(&cfg, WithSecretDetection(), WithAllowedKeys("cache_key"))
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	expected := `{"cache_key":"users:","db_password":"***","host":"localhost"}`
+	if masked != expected {
+		t.Errorf("Expected %s, got %s", expected, masked)
+	}
+
+	m, err := MaskedMap(&cfg, WithSecretKeyPatterns("host"))
+	if err != nil {
+		t.Fatalf("MaskedMap failed: %v", err)
+	}
+	if m["host"] != "***" || m["db_password"] != "hunter2" {
+		t.Errorf("Expected only custom patterns to apply, got %v", m)
+	}
+}
+
+func TestBindSelfReferentialAndInvalidTargets(t *testing.T) {
+	type Node struct {
+		Name string   `config:"name"`
+		Tags []string `config:"tags" merge:"append"`
+		Next *Node    `config:"next"`
+	}
+
+	yamlPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "name: a\nnext:\n  name: b\n  next:\n    name: c\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+
+	var node Node
+	if err := New().FromFile(yamlPath).Strict().Bind(&node); err != nil {
+		t.Fatalf("Failed to bind self-referential type: %v", err)
+	}
+	if node.Name != "a" || node.Next == nil || node.Next.Next == nil || node.Next.Next.Name != "c" || node.Next.Next.Next != nil {
+		t.Errorf("Unexpected values: %+v", node)
+	}
+
+	for _, target := range []any{nil, Node{}, (*Node)(nil)} {
+		err := New().FromFile(yamlPath).Bind(target)
+		if err == nil || !strings.Contains(err.Error(), "target must be a non-nil pointer") {
+			t.Errorf("Expected error for target %#v, got %v", target, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package goconfig
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+// GenerateKey returns a new random key for ENC[...] envelopes, base64 encoded
+// so it can be stored in a key file or an environment variable.
+func GenerateKey() (string, error) {
+	key := make([]byte, internal.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generating key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseKey decodes a base64 or hex encoded envelope key.
+func ParseKey(encoded string) ([]byte, error) {
+	return internal.ParseKey(encoded)
+}
+
+// EncryptValue encrypts plaintext into an `ENC[AES256_GCM,data:...,iv:...]`
+// envelope that can be committed to configuration files under the dotted key
+// path, e.g. "db.pass". The envelope fails to decrypt under any other key, so
+// it cannot be moved to another setting. Paths are compared case-insensitively.
+func EncryptValue(key []byte, path, plaintext string) (string, error) {
+	return internal.EncryptValue(key, path, plaintext)
+}
+
+// DecryptValue decrypts an envelope produced by EncryptValue for path.
+func DecryptValue(key []byte, path, envelope string) (string, error) {
+	return internal.DecryptValue(key, path, envelope)
+}
+
+func keyFromFile(path string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading key file %s: %w", path, err)
+		}
+		key, err := internal.ParseKey(string(b))
+		if err != nil {
+			return nil, fmt.Errorf("parsing key file %s: %w", path, err)
+		}
+		return key, nil
+	}
+}
+
+func keyFromEnv(name string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("key environment variable %s is not set", name)
+		}
+		key, err := internal.ParseKey(val)
+		if err != nil {
+			return nil, fmt.Errorf("parsing key from %s: %w", name, err)
+		}
+		return key, nil
+	}
+}
@@ -0,0 +1,9 @@
+package goconfig
+
+import "github.com/shkmv/goconfig/internal"
+
+// FieldError is returned, wrapped, by Bind when a configured value cannot be
+// bound to a field. It carries the full key and the field's type; for
+// fields marked secret, by tag, by a Secret type or by their source, the
+// message names only the type and never the value. Use errors.As to inspect it.
+type FieldError = internal.FieldError
@@ -2,4 +2,12 @@ module github.com/shkmv/goconfig
 
 go 1.24.2
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	filippo.io/age v1.2.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/crypto v0.24.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Alias is an alternative key a field also binds from, declared with the
+// `alias=<key>` or `deprecated=<key>` tag options, e.g.
+// `config:"db.host,deprecated=db.hostname"`. Alias keys are relative to the
+// same struct as the field's own key.
+type Alias struct {
+	// Key is the full dotted key of the field, e.g. "db.host".
+	Key string
+	// Old is the full dotted alias key, e.g. "db.hostname".
+	Old string
+	// Deprecated marks aliases that should no longer be used.
+	Deprecated bool
+}
+
+// Aliases collects the aliases declared on the fields of struct type t.
+func Aliases(t reflect.Type, opts Options) []Alias {
+	var out []Alias
+	_ = walkFields(t, opts, nil, func(path []string, field reflect.StructField) error {
+		name, options := fieldTag(field, opts)
+		if name == "" {
+			name = opts.Naming(field.Name)
+		}
+		prefix := path[:len(path)-len(strings.Split(name, "."))]
+		key := strings.Join(path, ".")
+		for _, o := range options {
+			o = strings.TrimSpace(o)
+			old, deprecated := strings.CutPrefix(o, "deprecated=")
+			if !deprecated {
+				var ok bool
+				if old, ok = strings.CutPrefix(o, "alias="); !ok {
+					continue
+				}
+			}
+			oldPath := append(append([]string{}, prefix...), strings.Split(old, ".")...)
+			out = append(out, Alias{Key: key, Old: strings.Join(oldPath, "."), Deprecated: deprecated})
+		}
+		return nil
+	})
+	return out
+}
+
+// ApplyAliases moves values set under alias keys in data to the key of their
+// field and returns the aliases that were used. It fails when both the alias
+// and the field's key are set to different values. With opts.FoldCase keys
+// are matched case-insensitively.
+func ApplyAliases(data map[string]any, aliases []Alias, opts Options) ([]Alias, error) {
+	var used []Alias
+	for _, a := range aliases {
+		oldKeys := strings.Split(a.Old, ".")
+		val, ok := lookupKeys(data, oldKeys, opts.FoldCase)
+		if !ok {
+			continue
+		}
+		newKeys := strings.Split(a.Key, ".")
+		if cur, exists := lookupKeys(data, newKeys, opts.FoldCase); exists && !reflect.DeepEqual(cur, val) {
+			return nil, fmt.Errorf("%s and its replacement %s are both set with different values", a.Old, a.Key)
+		}
+		setKeys(data, newKeys, val, opts.FoldCase)
+		deleteKeys(data, oldKeys, opts.FoldCase)
+		used = append(used, a)
+	}
+	return used, nil
+}
+
+func lookupKeys(data map[string]any, keys []string, fold bool) (any, bool) {
+	if fold {
+		return lookupFold(data, keys)
+	}
+	return lookup(data, keys)
+}
+
+// deleteKeys removes the value at keys and any map it leaves empty.
+func deleteKeys(data map[string]any, keys []string, fold bool) {
+	k := keys[0]
+	if fold {
+		k = matchKey(data, k)
+	}
+	if len(keys) == 1 {
+		delete(data, k)
+		return
+	}
+	sub, ok := data[k].(map[string]any)
+	if !ok {
+		return
+	}
+	deleteKeys(sub, keys[1:], fold)
+	if len(sub) == 0 {
+		delete(data, k)
+	}
+}
+
+// setKeys stores val at keys, creating intermediate maps as needed.
+func setKeys(data map[string]any, keys []string, val any, fold bool) {
+	k := keys[0]
+	if fold {
+		k = matchKey(data, k)
+	}
+	if len(keys) == 1 {
+		data[k] = val
+		return
+	}
+	sub, ok := data[k].(map[string]any)
+	if !ok {
+		sub = make(map[string]any)
+		data[k] = sub
+	}
+	setKeys(sub, keys[1:], val, fold)
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyAliases(t *testing.T) {
+	type DB struct {
+		Host string `config:"host,deprecated=hostname"`
+		Port int    `config:"port,alias=tcp.port"`
+	}
+	type Config struct {
+		DB DB `config:"db"`
+	}
+
+	aliases := Aliases(reflect.TypeOf(Config{}), Options{})
+	expected := []Alias{
+		{Key: "db.host", Old: "db.hostname", Deprecated: true},
+		{Key: "db.port", Old: "db.tcp.port"},
+	}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Fatalf("Aliases mismatch.\nGot:  %#v\nWant: %#v", aliases, expected)
+	}
+
+	t.Run("Old Keys Move", func(t *testing.T) {
+		data := map[string]any{"db": map[string]any{"hostname": "old", "tcp": map[string]any{"port": 5432}}}
+		used, err := ApplyAliases(data, aliases, Options{})
+		if err != nil {
+			t.Fatalf("ApplyAliases failed: %v", err)
+		}
+		if !reflect.DeepEqual(used, expected) {
+			t.Errorf("Expected both aliases used, got %#v", used)
+		}
+		want := map[string]any{"db": map[string]any{"host": "old", "port": 5432}}
+		if !reflect.DeepEqual(data, want) {
+			t.Errorf("Data mismatch.\nGot:  %#v\nWant: %#v", data, want)
+		}
+	})
+
+	t.Run("Same Value", func(t *testing.T) {
+		data := map[string]any{"db": map[string]any{"hostname": "h", "host": "h"}}
+		if _, err := ApplyAliases(data, aliases, Options{}); err != nil {
+			t.Fatalf("ApplyAliases failed: %v", err)
+		}
+		if _, ok := lookup(data, []string{"db", "hostname"}); ok {
+			t.Errorf("Expected old key to be removed, got %#v", data)
+		}
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		data := map[string]any{"db": map[string]any{"hostname": "old", "host": "new"}}
+		if _, err := ApplyAliases(data, aliases, Options{}); err == nil {
+			t.Error("Expected error for conflicting values, got nil")
+		}
+	})
+
+	t.Run("Fold Case", func(t *testing.T) {
+		data := map[string]any{"DB": map[string]any{"HostName": "old"}}
+		if _, err := ApplyAliases(data, aliases, Options{FoldCase: true}); err != nil {
+			t.Fatalf("ApplyAliases failed: %v", err)
+		}
+		want := map[string]any{"DB": map[string]any{"host": "old"}}
+		if !reflect.DeepEqual(data, want) {
+			t.Errorf("Data mismatch.\nGot:  %#v\nWant: %#v", data, want)
+		}
+	})
+}
@@ -1,14 +1,48 @@
 package internal
 
 import (
-    "fmt"
-    "reflect"
-    "strings"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
+// Options controls optional binding behavior.
+type Options struct {
+	// ZeroNull zeroes non-nilable fields bound to an explicit null. By default
+	// such fields keep their current (default) value.
+	ZeroNull bool
+	// FoldCase matches `config` tags against keys case-insensitively.
+	FoldCase bool
+	// Naming derives the key of fields without a `config` tag from the field
+	// name. When nil, untagged fields are skipped.
+	Naming func(field string) string
+	// TagNames lists the struct tags keys are read from, in order of
+	// precedence. It defaults to DefaultTagName.
+	TagNames []string
+	// Secrets lists dotted keys whose values came from a secret source, such
+	// as credentials or resolved references. Bind errors and SanitizeWith
+	// mask them.
+	Secrets []string
+}
+
 // Bind recursively binds data from a map to the fields of a target struct
 // based on `config` tags.
 func Bind(data map[string]any, target any) error {
+	return BindWithOptions(data, target, Options{})
+}
+
+// BindWithOptions is like Bind but with optional behavior controlled by opts.
+//
+// An explicit null resets nilable fields (pointers, slices, maps, interfaces)
+// to nil and is otherwise treated as unset, unless opts.ZeroNull is set.
+// A required field bound to null is reported as missing.
+func BindWithOptions(data map[string]any, target any, opts Options) error {
 	v := reflect.ValueOf(target)
 	if v.Kind() != reflect.Ptr || v.IsNil() {
 		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
@@ -18,63 +52,309 @@ func Bind(data map[string]any, target any) error {
 	if v.Kind() != reflect.Struct {
 		return fmt.Errorf("target pointer must point to a struct, got %s", v.Kind())
 	}
+	b := &binder{opts: opts}
+	return b.bindStruct(data, v)
+}
+
+// binder carries the options through a recursive bind.
+type binder struct {
+	opts Options
+	// prefix is the key path of the struct being bound.
+	prefix []string
+	// secret is set while binding the fields of a secret struct.
+	secret bool
+}
+
+func (b *binder) bindStruct(data map[string]any, v reflect.Value) error {
 	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if squashed(field, b.opts) {
+			if err := b.bindSquashed(data, v.Field(i)); err != nil {
+				return fmt.Errorf("error binding embedded field %s: %w", field.Name, err)
+			}
+			continue
+		}
+		key, ok := fieldKey(field, b.opts)
+		if !ok {
+			continue
+		}
 
-    for i := range t.NumField() {
-        field := t.Field(i)
-        tag := field.Tag.Get("config")
-        if tag == "" || !field.IsExported() {
-            continue
-        }
-
-        // Check if the field is marked as required via `required:"true"` tag
-        isRequired := isTruthy(field.Tag.Get("required"))
-
-        keys := strings.Split(tag, ".")
-        val, ok := lookup(data, keys)
-        if !ok {
-            if isRequired {
-                return fmt.Errorf("missing required config key '%s' for field %s", tag, field.Name)
-            }
-            continue
-        }
+		// Check if the field is marked as required via `required:"true"` tag
+		isRequired := isTruthy(field.Tag.Get("required"))
+
+		keys := strings.Split(key, ".")
+		path := append(append([]string{}, b.prefix...), keys...)
+		val, ok := b.lookup(data, keys)
+		if !ok || (val == nil && isRequired) {
+			if isRequired {
+				return fmt.Errorf("missing required config key '%s' for field %s", strings.Join(path, "."), field.Name)
+			}
+			continue
+		}
 
 		fieldVal := v.Field(i)
 		if !fieldVal.CanSet() {
 			continue
 		}
 
+		if val == nil {
+			b.assignNull(fieldVal)
+			continue
+		}
+
+		if handled, err := b.assignCustom(fieldVal, val); handled {
+			if err != nil {
+				return b.fieldError(field, path, err)
+			}
+			continue
+		}
+
 		if fieldVal.Kind() == reflect.Struct {
-            if subData, ok := val.(map[string]any); ok {
-                if !fieldVal.CanAddr() {
-                    return fmt.Errorf("cannot get address of field %s to bind nested struct", field.Name)
-                }
-                if err := Bind(subData, fieldVal.Addr().Interface()); err != nil {
-                    return fmt.Errorf("error binding nested struct field %s: %w", field.Name, err)
-                }
-            } else if val != nil {
-                return fmt.Errorf("type mismatch for field %s: expected map[string]any for nested struct, got %T", field.Name, val)
-            }
-        } else if fieldVal.Kind() == reflect.Ptr && fieldVal.Elem().Kind() == reflect.Struct {
-            if subData, ok := val.(map[string]any); ok {
-                if fieldVal.IsNil() {
-                    newStruct := reflect.New(fieldVal.Type().Elem())
-                    fieldVal.Set(newStruct)
-                }
-                if err := Bind(subData, fieldVal.Interface()); err != nil {
-                    return fmt.Errorf("error binding nested pointer field %s: %w", field.Name, err)
-                }
-            } else if val != nil {
-                return fmt.Errorf("type mismatch for pointer field %s: expected map[string]any for nested struct pointer, got %T", field.Name, val)
-            }
-        } else {
-            if err := assing(fieldVal, val); err != nil {
-                return fmt.Errorf("error assigning value to field %s: %w", field.Name, err)
-            }
-        }
-    }
-
-    return nil
+			subData, ok := val.(map[string]any)
+			if !ok {
+				return b.fieldError(field, path, fmt.Errorf("type mismatch: expected map[string]any for nested struct, got %T", val))
+			}
+			if err := b.bindNested(subData, fieldVal, path, b.isSecret(field, strings.Join(path, "."))); err != nil {
+				return fmt.Errorf("error binding nested struct field %s: %w", field.Name, err)
+			}
+		} else if fieldVal.Kind() == reflect.Ptr && fieldVal.Type().Elem().Kind() == reflect.Struct && !bindsItself(fieldVal.Type().Elem()) {
+			subData, ok := val.(map[string]any)
+			if !ok {
+				return b.fieldError(field, path, fmt.Errorf("type mismatch: expected map[string]any for nested struct pointer, got %T", val))
+			}
+			if fieldVal.IsNil() {
+				fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+			}
+			if err := b.bindNested(subData, fieldVal.Elem(), path, b.isSecret(field, strings.Join(path, "."))); err != nil {
+				return fmt.Errorf("error binding nested pointer field %s: %w", field.Name, err)
+			}
+		} else {
+			if err := b.assignAt(fieldVal, val, path, b.isSecret(field, strings.Join(path, "."))); err != nil {
+				// Errors inside struct elements already name their full key.
+				var fe *FieldError
+				if errors.As(err, &fe) {
+					return fmt.Errorf("error binding field %s: %w", field.Name, err)
+				}
+				return b.fieldError(field, path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// assignAt assigns val to fieldVal found at key path, so that errors in
+// struct elements of slices and maps report keys such as "users.0.port".
+// All fields of secret elements are treated as secret.
+func (b *binder) assignAt(fieldVal reflect.Value, val any, path []string, secret bool) error {
+	savedPrefix, savedSecret := b.prefix, b.secret
+	b.prefix, b.secret = path, b.secret || secret
+	defer func() { b.prefix, b.secret = savedPrefix, savedSecret }()
+	return b.assign(fieldVal, val)
+}
+
+// bindNested binds data to the nested struct v found at key path. All
+// fields of a secret struct are treated as secret.
+func (b *binder) bindNested(data map[string]any, v reflect.Value, path []string, secret bool) error {
+	savedPrefix, savedSecret := b.prefix, b.secret
+	b.prefix, b.secret = path, b.secret || secret
+	defer func() { b.prefix, b.secret = savedPrefix, savedSecret }()
+	return b.bindStruct(data, v)
+}
+
+// bindSquashed binds data to the struct held by fieldVal as if its fields
+// were declared on the parent. A nil pointer is only allocated when data sets
+// at least one of its fields.
+func (b *binder) bindSquashed(data map[string]any, fieldVal reflect.Value) error {
+	if fieldVal.Kind() == reflect.Struct {
+		return b.bindStruct(data, fieldVal)
+	}
+	if !fieldVal.IsNil() {
+		return b.bindStruct(data, fieldVal.Elem())
+	}
+	if !fieldVal.CanSet() {
+		return nil
+	}
+	elem := reflect.New(fieldVal.Type().Elem())
+	if err := b.bindStruct(data, elem.Elem()); err != nil {
+		return err
+	}
+	if !elem.Elem().IsZero() {
+		fieldVal.Set(elem)
+	}
+	return nil
+}
+
+// assignNull applies an explicit null to fieldVal.
+func (b *binder) assignNull(fieldVal reflect.Value) {
+	switch fieldVal.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+	default:
+		if b.opts.ZeroNull {
+			fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		}
+	}
+}
+
+// assign assigns val to fieldVal, handling composite kinds and delegating
+// scalars to assing.
+func (b *binder) assign(fieldVal reflect.Value, val any) error {
+	if val == nil {
+		b.assignNull(fieldVal)
+		return nil
+	}
+	if handled, err := b.assignCustom(fieldVal, val); handled {
+		return err
+	}
+	if fieldVal.Type() == rawMessageType {
+		return assignRaw(fieldVal, val)
+	}
+	switch fieldVal.Kind() {
+	case reflect.Interface:
+		return assignInterface(fieldVal, val)
+	case reflect.Ptr:
+		return b.assignPtr(fieldVal, val)
+	case reflect.Slice:
+		return b.assignSlice(fieldVal, val)
+	case reflect.Map:
+		return b.assignMap(fieldVal, val)
+	case reflect.Struct:
+		sub, ok := val.(map[string]any)
+		if !ok {
+			return fmt.Errorf("type mismatch: expected map[string]any for struct %s, got %T", fieldVal.Type(), val)
+		}
+		return b.bindStruct(sub, fieldVal)
+	}
+	return assing(fieldVal, val)
+}
+
+// Binder is implemented by field types that wrap the value they bind, such
+// as goconfig.Secret. BindConfig is called with a function that binds the
+// configured value to target, a pointer to the wrapped value.
+type Binder interface {
+	BindConfig(bind func(target any) error) error
+}
+
+// binderType is the reflect.Type of Binder.
+var binderType = reflect.TypeFor[Binder]()
+
+// bindsItself reports whether values of type t are bound through Binder or
+// encoding.TextUnmarshaler rather than by their fields.
+func bindsItself(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t == yamlNodeType || pt.Implements(binderType) || pt.Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
+
+// assignCustom binds val to yaml.Node fields and fields that implement Binder
+// or encoding.TextUnmarshaler. It reports false for other fields.
+func (b *binder) assignCustom(fieldVal reflect.Value, val any) (bool, error) {
+	if fieldVal.Type() == yamlNodeType {
+		return true, assignNode(fieldVal, val)
+	}
+	if fieldVal.CanAddr() && fieldVal.Kind() != reflect.Ptr {
+		if c, ok := fieldVal.Addr().Interface().(Binder); ok {
+			return true, c.BindConfig(func(target any) error {
+				tv := reflect.ValueOf(target)
+				if tv.Kind() != reflect.Ptr || tv.IsNil() {
+					return fmt.Errorf("bind target must be a non-nil pointer, got %T", target)
+				}
+				return b.assign(tv.Elem(), val)
+			})
+		}
+	}
+	return unmarshalText(fieldVal, val)
+}
+
+// unmarshalText decodes string and number values into fields implementing
+// encoding.TextUnmarshaler, such as ByteSize or net.IP, so `size: 4096` in
+// YAML parses like APP_SIZE=4096. It reports false for other fields and
+// values, which are assigned by kind.
+func unmarshalText(fieldVal reflect.Value, val any) (bool, error) {
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case int, int64, uint64, float64:
+		str = fmt.Sprint(v)
+	default:
+		return false, nil
+	}
+	if !fieldVal.CanAddr() || fieldVal.Kind() == reflect.Ptr {
+		return false, nil
+	}
+	u, ok := fieldVal.Addr().Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return false, nil
+	}
+	if err := u.UnmarshalText([]byte(str)); err != nil {
+		return true, fmt.Errorf("cannot parse '%s' as %s: %w", str, fieldVal.Type(), err)
+	}
+	return true, nil
+}
+
+// rawMessageType is the type of json.RawMessage fields, which receive their
+// subtree re-encoded as JSON.
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// assignRaw stores val, typically a free-form subtree, as JSON in fieldVal.
+func assignRaw(fieldVal reflect.Value, val any) error {
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("encoding %T as JSON: %w", val, err)
+	}
+	fieldVal.SetBytes(raw)
+	return nil
+}
+
+// yamlNodeType is the type of yaml.Node fields, which receive their subtree
+// as a node to decode later, e.g. into a type chosen by another key.
+var yamlNodeType = reflect.TypeFor[yaml.Node]()
+
+// assignNode stores val, typically a free-form subtree, as a yaml.Node in
+// fieldVal.
+func assignNode(fieldVal reflect.Value, val any) error {
+	var node yaml.Node
+	if err := node.Encode(val); err != nil {
+		return fmt.Errorf("encoding %T as YAML: %w", val, err)
+	}
+	fieldVal.Set(reflect.ValueOf(node))
+	return nil
+}
+
+// assignInterface stores val as is in an interface field such as any, so
+// free-form subtrees keep their map[string]any and []any shape.
+func assignInterface(fieldVal reflect.Value, val any) error {
+	rv := reflect.ValueOf(val)
+	if !rv.Type().AssignableTo(fieldVal.Type()) {
+		return fmt.Errorf("type mismatch: %T does not implement %s", val, fieldVal.Type())
+	}
+	fieldVal.Set(rv)
+	return nil
+}
+
+// assignPtr binds val to a freshly allocated value and points fieldVal at it,
+// so a nil pointer field tells an absent key from an explicit zero value.
+// The previous pointee is copied first, but never modified.
+func (b *binder) assignPtr(fieldVal reflect.Value, val any) error {
+	elem := reflect.New(fieldVal.Type().Elem())
+	if !fieldVal.IsNil() {
+		elem.Elem().Set(fieldVal.Elem())
+	}
+	if err := b.assign(elem.Elem(), val); err != nil {
+		return err
+	}
+	fieldVal.Set(elem)
+	return nil
+}
+
+// lookup finds keys in data, honoring FoldCase.
+func (b *binder) lookup(data map[string]any, keys []string) (any, bool) {
+	if b.opts.FoldCase {
+		return lookupFold(data, keys)
+	}
+	return lookup(data, keys)
 }
 
 func lookup(data map[string]any, keys []string) (any, bool) {
@@ -98,25 +378,28 @@ func lookup(data map[string]any, keys []string) (any, bool) {
 	return nil, false
 }
 
-// assing assigns a value to a reflect.Value field, handling basic type conversions.
-func assing(fieldVal reflect.Value, val any) error {
-	if val == nil {
-		// Cannot assign nil directly unless it's a pointer, slice, map, etc.
-		switch fieldVal.Kind() {
-		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
-			if fieldVal.IsNil() {
-				return nil
-			}
-			return fmt.Errorf("cannot assign nil to non-nil field %s", fieldVal.Type())
-		default:
-			return fmt.Errorf("cannot assign nil to field %s of kind %s", fieldVal.Type(), fieldVal.Kind())
-		}
-		// TODO: Implement support for setting nil for nilable types if this feature will be needed.
-		// zeroValue := reflect.Zero(fieldVal.Type())
-		// fieldVal.Set(zeroValue)
-		// return nil
+// lookupFold is like lookup but matches keys case-insensitively, preferring
+// an exact match.
+func lookupFold(data map[string]any, keys []string) (any, bool) {
+	if len(keys) == 0 || data == nil {
+		return nil, false
+	}
+	val, ok := data[matchKey(data, keys[0])]
+	if !ok {
+		return nil, false
+	}
+	if len(keys) == 1 {
+		return val, true
+	}
+	if subdata, ok := val.(map[string]any); ok {
+		return lookupFold(subdata, keys[1:])
 	}
+	return nil, false
+}
 
+// assing assigns a value to a reflect.Value field, handling basic type
+// conversions. Nulls and composite kinds are handled by binder.assign.
+func assing(fieldVal reflect.Value, val any) error {
 	if strVal, ok := val.(string); ok {
 		switch fieldVal.Kind() {
 		case reflect.String:
@@ -241,13 +524,71 @@ func assing(fieldVal reflect.Value, val any) error {
 	return nil
 }
 
+// assignSlice assigns a list to a slice field, converting every element.
+// Strings are split on commas, so env values like "a,b,c" bind to []string.
+func (b *binder) assignSlice(fieldVal reflect.Value, val any) error {
+	var items []any
+	switch v := val.(type) {
+	case []any:
+		items = v
+	case string:
+		if strings.TrimSpace(v) != "" {
+			for _, part := range strings.Split(v, ",") {
+				items = append(items, strings.TrimSpace(part))
+			}
+		}
+	default:
+		rv := reflect.ValueOf(val)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Errorf("type mismatch: cannot assign %T to field of type %s", val, fieldVal.Type())
+		}
+		for i := range rv.Len() {
+			items = append(items, rv.Index(i).Interface())
+		}
+	}
+
+	out := reflect.MakeSlice(fieldVal.Type(), len(items), len(items))
+	prefix := b.prefix
+	for i, item := range items {
+		if err := b.assignAt(out.Index(i), item, append(append([]string{}, prefix...), strconv.Itoa(i)), false); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	fieldVal.Set(out)
+	return nil
+}
+
+// assignMap assigns a nested map to a map field with string keys,
+// converting every value.
+func (b *binder) assignMap(fieldVal reflect.Value, val any) error {
+	m, ok := val.(map[string]any)
+	if !ok {
+		return fmt.Errorf("type mismatch: cannot assign %T to field of type %s", val, fieldVal.Type())
+	}
+	if fieldVal.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported map key type %s, only string keys are supported", fieldVal.Type().Key())
+	}
+
+	out := reflect.MakeMapWithSize(fieldVal.Type(), len(m))
+	prefix := b.prefix
+	for k, item := range m {
+		elem := reflect.New(fieldVal.Type().Elem()).Elem()
+		if err := b.assignAt(elem, item, append(append([]string{}, prefix...), k), false); err != nil {
+			return fmt.Errorf("key %s: %w", k, err)
+		}
+		out.SetMapIndex(reflect.ValueOf(k).Convert(fieldVal.Type().Key()), elem)
+	}
+	fieldVal.Set(out)
+	return nil
+}
+
 // isTruthy returns true if the provided string represents a truthy value.
 // Accepts: "true", "1", "yes", "y", "on" (case-insensitive).
 func isTruthy(s string) bool {
-    switch strings.ToLower(strings.TrimSpace(s)) {
-    case "true", "1", "yes", "y", "on":
-        return true
-    default:
-        return false
-    }
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1", "yes", "y", "on":
+		return true
+	default:
+		return false
+	}
 }
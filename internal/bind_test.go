@@ -1,10 +1,14 @@
 package internal
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 	"reflect"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestBind(t *testing.T) {
@@ -178,8 +182,12 @@ func TestAssing(t *testing.T) {
 		{"Assign Float32", reflect.Float32, float32(0.0), 12.34, float64(12.34), false}, // Note: SetFloat takes float64
 		{"Assign Bool True", reflect.Bool, false, true, true, false},
 		{"Assign Bool False", reflect.Bool, true, false, false, false},
-		{"Unsupported Type Slice", reflect.Slice, []int{}, []int{1}, nil, true}, // Example of an unsupported type
+		{"Unsupported Type Chan", reflect.Chan, make(chan int), 1, nil, true}, // Example of an unsupported type
 		{"Unsupported Type Struct", reflect.Struct, struct{}{}, struct{}{}, nil, true},
+		{"Unsupported Type Slice", reflect.Slice, []int{}, []any{1}, nil, true}, // Composite kinds go through binder.assign
+		{"Unsupported Type Map", reflect.Map, map[string]int{}, map[string]any{"a": 1}, nil, true},
+		{"Unsupported Type Ptr", reflect.Ptr, new(int), 1, nil, true},
+		{"Unsupported Nil", reflect.Int, 0, nil, nil, true},
 		{"Assign Int from String", reflect.Int, 0, "123", int64(123), false},
 		{"Assign Int64 from String", reflect.Int64, int64(0), "456", int64(456), false},
 		{"Assign Float64 from String", reflect.Float64, 0.0, "789.12", 789.12, false},
@@ -259,3 +267,506 @@ func TestAssing(t *testing.T) {
 		}
 	})
 }
+
+func TestBindSlicesAndMaps(t *testing.T) {
+	type Upstream struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+	type Config struct {
+		Hosts     []string          `config:"hosts"`
+		Ports     []int             `config:"ports"`
+		Upstreams []Upstream        `config:"upstreams"`
+		Labels    map[string]string `config:"labels"`
+		Limits    map[string]int    `config:"limits"`
+	}
+
+	data := map[string]any{
+		"hosts": "a, b,c",
+		"ports": []any{80, "443"},
+		"upstreams": []any{
+			map[string]any{"host": "u1", "port": 8080},
+		},
+		"labels": map[string]any{"team": "core"},
+		"limits": map[string]any{"rps": "100"},
+	}
+
+	var target Config
+	if err := Bind(data, &target); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	expected := Config{
+		Hosts:     []string{"a", "b", "c"},
+		Ports:     []int{80, 443},
+		Upstreams: []Upstream{{Host: "u1", Port: 8080}},
+		Labels:    map[string]string{"team": "core"},
+		Limits:    map[string]int{"rps": 100},
+	}
+	if !reflect.DeepEqual(target, expected) {
+		t.Errorf("Bind result mismatch.\nGot:  %#v\nWant: %#v", target, expected)
+	}
+
+	if err := Bind(map[string]any{"ports": []any{"x"}}, &target); err == nil {
+		t.Error("Expected error for invalid slice element, got nil")
+	}
+}
+
+func TestSanitizeStructElements(t *testing.T) {
+	type User struct {
+		Name  string `config:"name"`
+		Pass  string `config:"pass" secret:"true"`
+		Token string `config:"token"`
+	}
+	type Config struct {
+		Users  []User           `config:"users"`
+		Admins map[string]*User `config:"admins"`
+		None   []User           `config:"none"`
+	}
+
+	target := Config{
+		Users:  []User{{Name: "a", Pass: "hunter2", Token: "t0"}},
+		Admins: map[string]*User{"root": {Name: "r", Pass: "swordfish"}, "gone": nil},
+	}
+	masked, err := SanitizeWith(&target, MaskOptions{Fields: Options{Secrets: []string{"users.0.token"}}})
+	if err != nil {
+		t.Fatalf("SanitizeWith failed: %v", err)
+	}
+	want := map[string]any{
+		"users": []any{map[string]any{"name": "a", "pass": "***", "token": "***"}},
+		"admins": map[string]any{
+			"root": map[string]any{"name": "r", "pass": "***", "token": ""},
+			"gone": nil,
+		},
+		"none": nil,
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("Sanitize mismatch.\nGot:  %#v\nWant: %#v", masked, want)
+	}
+}
+
+func TestBindNull(t *testing.T) {
+	type Inner struct {
+		Value string `config:"value"`
+	}
+	type Config struct {
+		Name   string            `config:"name"`
+		Port   int               `config:"port"`
+		Tags   []string          `config:"tags"`
+		Labels map[string]string `config:"labels"`
+		Inner  *Inner            `config:"inner"`
+	}
+	data := map[string]any{"name": nil, "port": nil, "tags": nil, "labels": nil, "inner": nil}
+	initial := Config{
+		Name:   "default",
+		Port:   8080,
+		Tags:   []string{"a"},
+		Labels: map[string]string{"k": "v"},
+		Inner:  &Inner{Value: "x"},
+	}
+
+	t.Run("Defaults Kept", func(t *testing.T) {
+		target := initial
+		if err := Bind(data, &target); err != nil {
+			t.Fatalf("Bind failed: %v", err)
+		}
+		expected := Config{Name: "default", Port: 8080}
+		if !reflect.DeepEqual(target, expected) {
+			t.Errorf("Bind result mismatch.\nGot:  %#v\nWant: %#v", target, expected)
+		}
+	})
+
+	t.Run("Zero Null", func(t *testing.T) {
+		target := initial
+		if err := BindWithOptions(data, &target, Options{ZeroNull: true}); err != nil {
+			t.Fatalf("Bind failed: %v", err)
+		}
+		if !reflect.DeepEqual(target, Config{}) {
+			t.Errorf("Expected all fields zeroed, got %#v", target)
+		}
+	})
+
+	t.Run("Required Null", func(t *testing.T) {
+		type Req struct {
+			Name string `config:"name" required:"true"`
+		}
+		var target Req
+		if err := Bind(map[string]any{"name": nil}, &target); err == nil {
+			t.Error("Expected error for required field set to null, got nil")
+		}
+	})
+}
+
+func TestBindNaming(t *testing.T) {
+	type Server struct {
+		HTTPPort int
+		MaxConns int `config:"max"`
+	}
+	type Config struct {
+		DBHost   string
+		Password string `secret:"true"`
+		Server   Server
+		Ignored  string `config:"-"`
+		internal string
+	}
+	data := map[string]any{
+		"db_host":  "localhost",
+		"password": "hunter2",
+		"server":   map[string]any{"http_port": 8080, "max": 10},
+		"ignored":  "set",
+		"internal": "set",
+	}
+
+	var plain Config
+	if err := Bind(data, &plain); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if !reflect.DeepEqual(plain, Config{}) {
+		t.Errorf("Expected untagged fields to be skipped without naming, got %#v", plain)
+	}
+
+	var target Config
+	if err := BindWithOptions(data, &target, Options{Naming: SnakeCase}); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	expected := Config{DBHost: "localhost", Password: "hunter2", Server: Server{HTTPPort: 8080, MaxConns: 10}}
+	if !reflect.DeepEqual(target, expected) {
+		t.Errorf("Bind result mismatch.\nGot:  %#v\nWant: %#v", target, expected)
+	}
+
+	masked, err := SanitizeWith(&target, MaskOptions{Fields: Options{Naming: SnakeCase}})
+	if err != nil {
+		t.Fatalf("SanitizeWith failed: %v", err)
+	}
+	want := map[string]any{
+		"db_host":  "localhost",
+		"password": "***",
+		"server":   map[string]any{"http_port": 8080, "max": 10},
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("Sanitize mismatch.\nGot:  %#v\nWant: %#v", masked, want)
+	}
+}
+
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		name, snake, kebab, lower string
+	}{
+		{"Host", "host", "host", "host"},
+		{"MaxConns", "max_conns", "max-conns", "maxconns"},
+		{"HTTPServer", "http_server", "http-server", "httpserver"},
+		{"DBHost", "db_host", "db-host", "dbhost"},
+		{"UserID", "user_id", "user-id", "userid"},
+	}
+	for _, tt := range tests {
+		if got := SnakeCase(tt.name); got != tt.snake {
+			t.Errorf("SnakeCase(%q) = %q, want %q", tt.name, got, tt.snake)
+		}
+		if got := KebabCase(tt.name); got != tt.kebab {
+			t.Errorf("KebabCase(%q) = %q, want %q", tt.name, got, tt.kebab)
+		}
+		if got := LowerCase(tt.name); got != tt.lower {
+			t.Errorf("LowerCase(%q) = %q, want %q", tt.name, got, tt.lower)
+		}
+	}
+}
+
+func TestBindEmbeddedStructs(t *testing.T) {
+	type Base struct {
+		Name  string `config:"name"`
+		Debug bool   `config:"debug"`
+	}
+	type logging struct {
+		Level string `config:"level"`
+	}
+	type TLS struct {
+		Cert string `config:"cert"`
+		Key  string `config:"key" secret:"true"`
+	}
+	type Config struct {
+		Base
+		logging
+		TLS    *TLS `config:",squash"`
+		Nested Base `config:"nested"`
+	}
+	data := map[string]any{
+		"name":   "app",
+		"debug":  true,
+		"level":  "info",
+		"cert":   "cert.pem",
+		"key":    "private",
+		"nested": map[string]any{"name": "inner"},
+	}
+
+	var target Config
+	if err := Bind(data, &target); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	expected := Config{
+		Base:    Base{Name: "app", Debug: true},
+		logging: logging{Level: "info"},
+		TLS:     &TLS{Cert: "cert.pem", Key: "private"},
+		Nested:  Base{Name: "inner"},
+	}
+	if !reflect.DeepEqual(target, expected) {
+		t.Errorf("Bind result mismatch.\nGot:  %#v\nWant: %#v", target, expected)
+	}
+
+	var empty Config
+	if err := Bind(map[string]any{"name": "app"}, &empty); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if empty.TLS != nil {
+		t.Errorf("Expected squashed pointer to stay nil when none of its keys are set, got %#v", empty.TLS)
+	}
+
+	masked, err := Sanitize(&target)
+	if err != nil {
+		t.Fatalf("Sanitize failed: %v", err)
+	}
+	want := map[string]any{
+		"name":   "app",
+		"debug":  true,
+		"level":  "info",
+		"cert":   "cert.pem",
+		"key":    "***",
+		"nested": map[string]any{"name": "inner", "debug": false},
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("Sanitize mismatch.\nGot:  %#v\nWant: %#v", masked, want)
+	}
+
+	if unknown := UnknownKeys(data, reflect.TypeOf(Config{}), Options{}); len(unknown) != 0 {
+		t.Errorf("Expected promoted keys to be known, got %v", unknown)
+	}
+}
+
+func TestBindTagNames(t *testing.T) {
+	type Common struct {
+		Region string `mapstructure:"region"`
+	}
+	type Config struct {
+		Common   `mapstructure:",squash"`
+		Host     string `yaml:"host,omitempty" json:"hostname"`
+		Port     int    `yaml:",omitempty" json:"port"`
+		Password string `mapstructure:"password" secret:"true"`
+		Override string `config:"custom" yaml:"override"`
+		Skipped  string `yaml:"-"`
+	}
+	data := map[string]any{
+		"region":   "eu",
+		"host":     "localhost",
+		"hostname": "ignored",
+		"port":     8080,
+		"password": "hunter2",
+		"custom":   "from-config",
+		"override": "from-yaml",
+		"skipped":  "x",
+	}
+	opts := Options{TagNames: []string{"config", "yaml", "json", "mapstructure"}}
+
+	var target Config
+	if err := BindWithOptions(data, &target, opts); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	expected := Config{
+		Common:   Common{Region: "eu"},
+		Host:     "localhost",
+		Port:     8080,
+		Password: "hunter2",
+		Override: "from-config",
+	}
+	if !reflect.DeepEqual(target, expected) {
+		t.Errorf("Bind result mismatch.\nGot:  %#v\nWant: %#v", target, expected)
+	}
+
+	masked, err := SanitizeWith(&target, MaskOptions{Fields: opts})
+	if err != nil {
+		t.Fatalf("SanitizeWith failed: %v", err)
+	}
+	want := map[string]any{
+		"region":   "eu",
+		"host":     "localhost",
+		"port":     8080,
+		"password": "***",
+		"custom":   "from-config",
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("Sanitize mismatch.\nGot:  %#v\nWant: %#v", masked, want)
+	}
+
+	type EnvConfig struct {
+		Host string `env:"DB_HOST"`
+		Port int    `env:"PORT"`
+	}
+	var env EnvConfig
+	envData := map[string]any{"db": map[string]any{"host": "localhost"}, "port": 8080}
+	if err := BindWithOptions(envData, &env, Options{TagNames: []string{"env"}}); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if env.Host != "localhost" || env.Port != 8080 {
+		t.Errorf("Expected env tags to map like EnvSource, got %+v", env)
+	}
+}
+
+func TestBindPointers(t *testing.T) {
+	type Config struct {
+		Enabled  *bool              `config:"enabled"`
+		Port     *int               `config:"port"`
+		Name     *string            `config:"name"`
+		Token    *string            `config:"token" secret:"true"`
+		Timeout  *float64           `config:"timeout"`
+		Tags     *[]string          `config:"tags"`
+		Labels   *map[string]string `config:"labels"`
+		Replicas []*int             `config:"replicas"`
+	}
+	data := map[string]any{
+		"enabled":  false,
+		"port":     "8080",
+		"token":    "secret",
+		"tags":     []any{"a", "b"},
+		"labels":   map[string]any{"k": "v"},
+		"replicas": []any{1, 2},
+	}
+
+	var target Config
+	if err := Bind(data, &target); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if target.Enabled == nil || *target.Enabled {
+		t.Errorf("Expected explicit false, got %v", target.Enabled)
+	}
+	if target.Port == nil || *target.Port != 8080 {
+		t.Errorf("Expected port 8080, got %v", target.Port)
+	}
+	if target.Name != nil || target.Timeout != nil {
+		t.Errorf("Expected absent keys to stay nil, got %v, %v", target.Name, target.Timeout)
+	}
+	if target.Tags == nil || !reflect.DeepEqual(*target.Tags, []string{"a", "b"}) {
+		t.Errorf("Unexpected tags: %v", target.Tags)
+	}
+	if target.Labels == nil || !reflect.DeepEqual(*target.Labels, map[string]string{"k": "v"}) {
+		t.Errorf("Unexpected labels: %v", target.Labels)
+	}
+	if len(target.Replicas) != 2 || *target.Replicas[0] != 1 || *target.Replicas[1] != 2 {
+		t.Errorf("Unexpected replicas: %v", target.Replicas)
+	}
+
+	shared := 1
+	other := Config{Port: &shared}
+	if err := Bind(map[string]any{"port": 2}, &other); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if shared != 1 || *other.Port != 2 {
+		t.Errorf("Expected a new pointee, got shared=%d port=%d", shared, *other.Port)
+	}
+
+	if err := Bind(map[string]any{"port": nil}, &other); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if other.Port != nil {
+		t.Errorf("Expected null to reset pointer, got %v", *other.Port)
+	}
+
+	masked, err := Sanitize(&target)
+	if err != nil {
+		t.Fatalf("Sanitize failed: %v", err)
+	}
+	want := map[string]any{
+		"enabled":  false,
+		"port":     8080,
+		"name":     nil,
+		"token":    "***",
+		"timeout":  nil,
+		"tags":     []string{"a", "b"},
+		"labels":   map[string]string{"k": "v"},
+		"replicas": target.Replicas,
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Errorf("Sanitize mismatch.\nGot:  %#v\nWant: %#v", masked, want)
+	}
+}
+
+func TestBindRawSubtrees(t *testing.T) {
+	type Plugin struct {
+		Settings map[string]any  `config:"settings"`
+		Extra    any             `config:"extra"`
+		Raw      json.RawMessage `config:"raw"`
+		Count    any             `config:"count"`
+	}
+	type Config struct {
+		Plugin Plugin `config:"plugin"`
+	}
+	subtree := map[string]any{"retries": 3, "hosts": []any{"a", "b"}, "tls": map[string]any{"verify": true}}
+	data := map[string]any{
+		"plugin": map[string]any{
+			"settings": subtree,
+			"extra":    subtree,
+			"raw":      subtree,
+			"count":    2,
+		},
+	}
+
+	var target Config
+	if err := Bind(data, &target); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if !reflect.DeepEqual(target.Plugin.Settings, subtree) {
+		t.Errorf("Settings mismatch: %#v", target.Plugin.Settings)
+	}
+	if !reflect.DeepEqual(target.Plugin.Extra, subtree) {
+		t.Errorf("Extra mismatch: %#v", target.Plugin.Extra)
+	}
+	if target.Plugin.Count != 2 {
+		t.Errorf("Expected count 2, got %#v", target.Plugin.Count)
+	}
+
+	var decoded struct {
+		Retries int      `json:"retries"`
+		Hosts   []string `json:"hosts"`
+		TLS     struct {
+			Verify bool `json:"verify"`
+		} `json:"tls"`
+	}
+	if err := json.Unmarshal(target.Plugin.Raw, &decoded); err != nil {
+		t.Fatalf("Unmarshal of raw subtree failed: %v", err)
+	}
+	if decoded.Retries != 3 || len(decoded.Hosts) != 2 || !decoded.TLS.Verify {
+		t.Errorf("Unexpected decoded raw subtree: %+v", decoded)
+	}
+
+	type NodeConfig struct {
+		Node    yaml.Node  `config:"node"`
+		NodePtr *yaml.Node `config:"node_ptr"`
+	}
+	var nodes NodeConfig
+	if err := Bind(map[string]any{"node": subtree, "node_ptr": []any{"a", "b"}}, &nodes); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if err := nodes.Node.Decode(&decoded); err != nil {
+		t.Fatalf("Decode of node failed: %v", err)
+	}
+	if decoded.Retries != 3 || len(decoded.Hosts) != 2 || !decoded.TLS.Verify {
+		t.Errorf("Unexpected decoded node: %+v", decoded)
+	}
+	var hosts []string
+	if nodes.NodePtr == nil || nodes.NodePtr.Decode(&hosts) != nil || strings.Join(hosts, ",") != "a,b" {
+		t.Errorf("Unexpected node pointer: %#v", nodes.NodePtr)
+	}
+	if unknown := UnknownKeys(map[string]any{"node": subtree}, reflect.TypeOf(nodes), Options{Naming: SnakeCase}); len(unknown) > 0 {
+		t.Errorf("Expected keys below a node to be accepted, got %v", unknown)
+	}
+	masked, err := Sanitize(&nodes)
+	if err != nil {
+		t.Fatalf("Sanitize failed: %v", err)
+	}
+	if !reflect.DeepEqual(masked["node"], subtree) {
+		t.Errorf("Expected node to be shown as its subtree, got %#v", masked["node"])
+	}
+
+	type Stringer struct {
+		S fmt.Stringer `config:"s"`
+	}
+	if err := Bind(map[string]any{"s": 1}, &Stringer{}); err == nil {
+		t.Error("Expected error assigning int to fmt.Stringer, got nil")
+	}
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// DefaultSecretKeyPatterns are the key words that mark a value as secret
+// when detection is enabled without custom patterns.
+var DefaultSecretKeyPatterns = []string{
+	"password", "passwd", "secret", "token", "key", "apikey", "credential", "credentials", "dsn",
+}
+
+// MaskOptions controls SanitizeWith.
+type MaskOptions struct {
+	// Fields controls how keys are derived from fields, as in
+	// BindWithOptions. Only Naming, TagNames and Secrets apply.
+	Fields Options
+	// Detect masks untagged values whose key or content looks like a secret.
+	Detect bool
+	// KeyPatterns are the words that mark a key as secret. A key matches
+	// when its last segment, or one of the words it is made of
+	// (db_password, apiKey), equals a pattern. Defaults to
+	// DefaultSecretKeyPatterns.
+	KeyPatterns []string
+	// Allow lists dotted keys, optionally with path.Match wildcards such as
+	// "*.token_ttl", that are never masked by detection.
+	Allow []string
+}
+
+// jwtPattern matches JSON Web Tokens, whose header always starts with `{"`.
+var jwtPattern = regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)
+
+// detectSecrets returns a copy of data with values masked whose key matches
+// opts.KeyPatterns or whose content looks like a credential: URLs with a
+// password, JWTs and PEM blocks. Maps and slices are copied as generic
+// map[string]any and []any values.
+func detectSecrets(data map[string]any, opts MaskOptions) map[string]any {
+	d := detector{opts: opts, patterns: opts.KeyPatterns}
+	if d.patterns == nil {
+		d.patterns = DefaultSecretKeyPatterns
+	}
+	return d.walk(data, nil).(map[string]any)
+}
+
+type detector struct {
+	opts     MaskOptions
+	patterns []string
+}
+
+func (d *detector) walk(val any, keys []string) any {
+	if len(keys) > 0 {
+		if d.allowed(keys) {
+			return val
+		}
+		if d.secretKey(keys[len(keys)-1]) {
+			return redactedValue
+		}
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return val
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			out[k] = d.walk(iter.Value().Interface(), append(append([]string{}, keys...), k))
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return val
+		}
+		out := make([]any, rv.Len())
+		for i := range rv.Len() {
+			out[i] = d.walkValue(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.String:
+		return maskCredential(rv.String(), val)
+	}
+	return val
+}
+
+// walkValue walks a list element, which has no key of its own.
+func (d *detector) walkValue(val any) any {
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return d.walk(val, nil)
+	}
+	if rv.Kind() == reflect.String {
+		return maskCredential(rv.String(), val)
+	}
+	return val
+}
+
+// allowed reports whether keys, or a key above it, is on the allowlist.
+func (d *detector) allowed(keys []string) bool {
+	for i := 1; i <= len(keys); i++ {
+		key := strings.ToLower(strings.Join(keys[:i], "."))
+		for _, pattern := range d.opts.Allow {
+			if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// secretKey reports whether a key segment matches a pattern as a whole, as
+// its words joined, or by one of its words.
+func (d *detector) secretKey(segment string) bool {
+	words := splitWords(segment)
+	candidates := append([]string{strings.ToLower(segment), strings.Join(words, "")}, words...)
+	for _, p := range d.patterns {
+		p = strings.ToLower(p)
+		for _, c := range candidates {
+			if c == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maskCredential masks s if it looks like a credential and otherwise returns
+// the original value val. URLs keep everything but their password and
+// credential query parameters.
+func maskCredential(s string, val any) any {
+	switch {
+	case s == redactedValue:
+		return val
+	case strings.Contains(s, "-----BEGIN "):
+		return redactedValue
+	case jwtPattern.MatchString(s):
+		return redactedValue
+	case strings.Contains(s, "://"):
+		if masked, found, _ := maskURLCredentials(s); found {
+			return masked
+		}
+	}
+	return val
+}
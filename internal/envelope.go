@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	envelopePrefix = "ENC["
+	envelopeSuffix = "]"
+	envelopeCipher = "AES256_GCM"
+	// KeySize is the length in bytes of envelope encryption keys.
+	KeySize = 32
+)
+
+// ParseKey decodes an envelope key given as base64 or hex text.
+// Surrounding whitespace, such as a trailing newline in a key file, is ignored.
+func ParseKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("key must be %d bytes encoded as base64 or hex", KeySize)
+}
+
+// IsEnvelope reports whether s looks like an ENC[...] envelope.
+func IsEnvelope(s string) bool {
+	return strings.HasPrefix(s, envelopePrefix) && strings.HasSuffix(s, envelopeSuffix)
+}
+
+// EncryptValue seals plaintext for the dotted key path, e.g. "db.pass", with
+// AES-256-GCM and returns it as `ENC[AES256_GCM,data:<base64>,iv:<base64>]`.
+// The path is authenticated, so the envelope only decrypts under that key.
+func EncryptValue(key []byte, path, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", fmt.Errorf("generating iv: %w", err)
+	}
+	data := gcm.Seal(nil, iv, []byte(plaintext), envelopeAAD(path))
+	return fmt.Sprintf("%s%s,data:%s,iv:%s%s", envelopePrefix, envelopeCipher,
+		base64.StdEncoding.EncodeToString(data), base64.StdEncoding.EncodeToString(iv), envelopeSuffix), nil
+}
+
+// DecryptValue opens an envelope produced by EncryptValue for the same path.
+func DecryptValue(key []byte, path, envelope string) (string, error) {
+	if !IsEnvelope(envelope) {
+		return "", fmt.Errorf("value is not an ENC[...] envelope")
+	}
+	body := envelope[len(envelopePrefix) : len(envelope)-len(envelopeSuffix)]
+	parts := strings.Split(body, ",")
+	if parts[0] != envelopeCipher {
+		return "", fmt.Errorf("unsupported envelope cipher %q", parts[0])
+	}
+
+	fields := make(map[string][]byte)
+	for _, part := range parts[1:] {
+		name, val, ok := strings.Cut(part, ":")
+		if !ok {
+			return "", fmt.Errorf("malformed envelope field %q", part)
+		}
+		switch name {
+		case "data", "iv":
+			b, err := base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return "", fmt.Errorf("decoding envelope %s: %w", name, err)
+			}
+			fields[name] = b
+		default:
+			// Unknown fields are ignored to allow future extensions.
+		}
+	}
+	if fields["data"] == nil || fields["iv"] == nil {
+		return "", fmt.Errorf("envelope must contain data and iv")
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(fields["iv"]) != gcm.NonceSize() {
+		return "", fmt.Errorf("envelope iv must be %d bytes", gcm.NonceSize())
+	}
+	plain, err := gcm.Open(nil, fields["iv"], fields["data"], envelopeAAD(path))
+	if err != nil {
+		return "", fmt.Errorf("decrypting envelope: %w", err)
+	}
+	return string(plain), nil
+}
+
+// DecryptEnvelopes replaces, in place, every ENC[...] string value in data with
+// its plaintext and returns the sorted dotted keys that were decrypted.
+func DecryptEnvelopes(data map[string]any, key []byte) ([]string, error) {
+	var decrypted []string
+	if err := decryptMap(data, key, nil, &decrypted); err != nil {
+		return nil, err
+	}
+	sort.Strings(decrypted)
+	return decrypted, nil
+}
+
+func decryptMap(data map[string]any, key []byte, prefix []string, decrypted *[]string) error {
+	for k, v := range data {
+		path := append(append([]string{}, prefix...), k)
+		switch val := v.(type) {
+		case map[string]any:
+			if err := decryptMap(val, key, path, decrypted); err != nil {
+				return err
+			}
+		case string:
+			if !IsEnvelope(val) {
+				continue
+			}
+			plain, err := DecryptValue(key, strings.Join(path, "."), val)
+			if err != nil {
+				return fmt.Errorf("decrypting %s: %w", strings.Join(path, "."), err)
+			}
+			data[k] = plain
+			*decrypted = append(*decrypted, strings.Join(path, "."))
+		}
+	}
+	return nil
+}
+
+// envelopeAAD returns the additional data binding an envelope to its key
+// path. Paths are lowercased, as sources differ in key case.
+func envelopeAAD(path string) []byte {
+	return []byte(strings.ToLower(path))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating gcm: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, KeySize)
+
+	env, err := EncryptValue(key, "db.pass", "hunter2")
+	if err != nil {
+		t.Fatalf("EncryptValue failed: %v", err)
+	}
+	if !IsEnvelope(env) {
+		t.Fatalf("Expected ENC[...] envelope, got %q", env)
+	}
+
+	plain, err := DecryptValue(key, "DB.Pass", env)
+	if err != nil {
+		t.Fatalf("DecryptValue failed: %v", err)
+	}
+	if plain != "hunter2" {
+		t.Errorf("Expected 'hunter2', got %q", plain)
+	}
+
+	if _, err := DecryptValue(bytes.Repeat([]byte{8}, KeySize), "db.pass", env); err == nil {
+		t.Error("Expected error decrypting with the wrong key, got nil")
+	}
+	if _, err := DecryptValue(key, "admin.pass", env); err == nil {
+		t.Error("Expected error decrypting under another key path, got nil")
+	}
+}
+
+func TestDecryptEnvelopes(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, KeySize)
+	env, err := EncryptValue(key, "db.pass", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]any{
+		"db":   map[string]any{"pass": env, "host": "localhost"},
+		"port": 8080,
+	}
+	keys, err := DecryptEnvelopes(data, key)
+	if err != nil {
+		t.Fatalf("DecryptEnvelopes failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"db":   map[string]any{"pass": "hunter2", "host": "localhost"},
+		"port": 8080,
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("DecryptEnvelopes mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+	if !reflect.DeepEqual(keys, []string{"db.pass"}) {
+		t.Errorf("Expected decrypted keys [db.pass], got %v", keys)
+	}
+
+	// An envelope copied to another key does not decrypt.
+	moved := map[string]any{"admin": map[string]any{"pass": env}}
+	if _, err := DecryptEnvelopes(moved, key); err == nil {
+		t.Error("Expected error for an envelope moved to another key, got nil")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	hexKey := "0707070707070707070707070707070707070707070707070707070707070707\n"
+	key, err := ParseKey(hexKey)
+	if err != nil {
+		t.Fatalf("ParseKey(hex) failed: %v", err)
+	}
+	if !bytes.Equal(key, bytes.Repeat([]byte{7}, KeySize)) {
+		t.Errorf("ParseKey(hex) returned %x", key)
+	}
+	if _, err := ParseKey("short"); err == nil {
+		t.Error("Expected error for invalid key, got nil")
+	}
+}
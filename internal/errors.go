@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// redactedValue replaces secret values in error messages.
+const redactedValue = "***"
+
+// FieldError reports a configured value that could not be bound to a field.
+// For secret fields the underlying error is replaced by a generic one naming
+// only the type, since parse errors often quote the value or parts of it.
+type FieldError struct {
+	// Key is the full dotted key, e.g. "db.port".
+	Key string
+	// Field is the name of the struct field, e.g. "Port".
+	Field string
+	// Type is the type of the field.
+	Type reflect.Type
+	// Secret reports whether the field holds a secret.
+	Secret bool
+	// Err describes why the value could not be bound.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("error assigning value to field %s (key %s, type %s): %v", e.Field, e.Key, e.Type, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// SecretMarker is implemented by field types that always hold a secret,
+// such as goconfig.Secret.
+type SecretMarker interface {
+	IsSecret() bool
+}
+
+// fieldError wraps err, caused by binding a value to field at key path, in a
+// FieldError, dropping err if the field holds a secret.
+func (b *binder) fieldError(field reflect.StructField, path []string, err error) error {
+	key := strings.Join(path, ".")
+	fe := &FieldError{Key: key, Field: field.Name, Type: field.Type, Err: err}
+	if b.isSecret(field, key) {
+		fe.Secret = true
+		fe.Err = fmt.Errorf("invalid value for %s", field.Type)
+	}
+	return fe
+}
+
+// isSecret reports whether field is marked secret by its tag, its type,
+// Options.Secrets or an enclosing secret struct.
+func (b *binder) isSecret(field reflect.StructField, key string) bool {
+	if _, ok := secretMode(field.Tag.Get("secret"), b.opts.secretKey(key)); ok || b.secret {
+		return true
+	}
+	return secretType(field.Type)
+}
+
+// secretType reports whether t, or the element type of a pointer, slice,
+// array or map t, implements SecretMarker, so []Secret[int] is secret too.
+func secretType(t reflect.Type) bool {
+	for {
+		if marker, ok := reflect.New(t).Interface().(SecretMarker); ok && marker.IsSecret() {
+			return true
+		}
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testSecret struct{ v int }
+
+func (testSecret) IsSecret() bool { return true }
+
+func (s *testSecret) BindConfig(bind func(target any) error) error { return bind(&s.v) }
+
+func TestBindErrorsMaskSecrets(t *testing.T) {
+	type DB struct {
+		Port    int        `config:"port"`
+		PIN     int        `config:"pin" secret:"true"`
+		Ratio   float64    `config:"ratio" secret:"true"`
+		Code    testSecret `config:"code"`
+		Flags   []int      `config:"flags" secret:"true"`
+		Timeout int        `config:"timeout"`
+
+		Codes []testSecret           `config:"codes"`
+		Keys  map[string]*testSecret `config:"keys"`
+		Pairs [2]testSecret          `config:"pairs"`
+	}
+	type Vault struct {
+		Token int `config:"token"`
+	}
+	type User struct {
+		Port int `config:"port"`
+		PIN  int `config:"pin" secret:"true"`
+	}
+	type Config struct {
+		DB     DB               `config:"db"`
+		Vault  Vault            `config:"vault" secret:"true"`
+		Users  []User           `config:"users"`
+		Admins map[string]*User `config:"admins"`
+		Keys   []User           `config:"keys" secret:"true"`
+	}
+
+	tests := []struct {
+		name   string
+		data   map[string]any
+		key    string
+		secret bool
+		value  string
+	}{
+		{"Plain", map[string]any{"db": map[string]any{"port": "eighty"}}, "db.port", false, ""},
+		{"Tagged", map[string]any{"db": map[string]any{"pin": "hunter2"}}, "db.pin", true, "hunter2"},
+		{"Float", map[string]any{"db": map[string]any{"ratio": "s3cr3t"}}, "db.ratio", true, "s3cr3t"},
+		{"Marker Type", map[string]any{"db": map[string]any{"code": "letmein"}}, "db.code", true, "letmein"},
+		{"Marker List", map[string]any{"db": map[string]any{"codes": []any{1, "hunter2"}}}, "db.codes", true, "hunter2"},
+		{"Marker Map", map[string]any{"db": map[string]any{"keys": map[string]any{"a": "hunter2"}}}, "db.keys", true, "hunter2"},
+		{"Marker Array", map[string]any{"db": map[string]any{"pairs": []any{"hunter2"}}}, "db.pairs", true, "hunter2"},
+		{"List", map[string]any{"db": map[string]any{"flags": []any{1, "opensesame"}}}, "db.flags", true, "opensesame"},
+		{"Secret Struct", map[string]any{"vault": map[string]any{"token": "swordfish"}}, "vault.token", true, "swordfish"},
+		{"Struct List", map[string]any{"users": []any{map[string]any{"port": 1}, map[string]any{"port": "eighty"}}}, "users.1.port", false, ""},
+		{"Struct List Secret", map[string]any{"users": []any{map[string]any{"pin": "hunter2"}}}, "users.0.pin", true, "hunter2"},
+		{"Struct Map", map[string]any{"admins": map[string]any{"root": map[string]any{"port": "eighty"}}}, "admins.root.port", false, ""},
+		{"Secret Struct List", map[string]any{"keys": []any{map[string]any{"port": "swordfish"}}}, "keys.0.port", true, "swordfish"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var target Config
+			err := Bind(tt.data, &target)
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Expected a FieldError, got %v", err)
+			}
+			if fe.Key != tt.key || fe.Secret != tt.secret {
+				t.Errorf("Unexpected FieldError %+v", fe)
+			}
+			if tt.secret && strings.Contains(err.Error(), tt.value) {
+				t.Errorf("Secret value leaked: %v", err)
+			}
+			if tt.secret && !strings.HasSuffix(err.Error(), "invalid value for "+fe.Type.String()) {
+				t.Errorf("Expected only the type in a secret error, got %v", err)
+			}
+			if !tt.secret && !strings.Contains(err.Error(), "eighty") {
+				t.Errorf("Expected plain value in error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Secret Key Option", func(t *testing.T) {
+		type Registered struct {
+			Timeout int `config:"timeout"`
+		}
+		err := BindWithOptions(map[string]any{"timeout": "tops3cret"}, &Registered{}, Options{Secrets: []string{"Timeout"}})
+		if err == nil || strings.Contains(err.Error(), "tops3cret") {
+			t.Errorf("Expected masked error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+)
+
+// parseTag splits a `config` tag into the key and its comma separated
+// options, e.g. "db,squash" into "db" and ["squash"].
+func parseTag(tag string) (string, []string) {
+	name, rest, found := strings.Cut(tag, ",")
+	if !found {
+		return name, nil
+	}
+	return name, strings.Split(rest, ",")
+}
+
+// hasOption reports whether options contains any of names.
+func hasOption(options []string, names ...string) bool {
+	for _, o := range options {
+		for _, n := range names {
+			if strings.TrimSpace(o) == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DefaultTagName is the struct tag keys are read from unless
+// Options.TagNames says otherwise.
+const DefaultTagName = "config"
+
+// envTagName is the struct tag holding environment variable names.
+const envTagName = "env"
+
+// fieldTag returns the key and options of the first tag of field, in the
+// order of opts.TagNames, that names a key, squashes the field or declares
+// aliases. Tags carrying only other options, such as `yaml:",omitempty"`,
+// are passed over. Names in `env` tags are mapped to keys the way EnvSource
+// maps variables, so `env:"DB_HOST"` binds db.host.
+func fieldTag(field reflect.StructField, opts Options) (string, []string) {
+	names := opts.TagNames
+	if len(names) == 0 {
+		names = []string{DefaultTagName}
+	}
+	for _, n := range names {
+		tag, ok := field.Tag.Lookup(n)
+		if !ok {
+			continue
+		}
+		name, options := parseTag(tag)
+		if n == envTagName && name != "-" {
+			name = strings.ToLower(strings.ReplaceAll(name, "_", "."))
+		}
+		if name != "" || hasOption(options, "squash", "inline") || hasAliases(options) {
+			return name, options
+		}
+	}
+	return "", nil
+}
+
+// hasAliases reports whether options declare alias keys.
+func hasAliases(options []string) bool {
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if strings.HasPrefix(o, "alias=") || strings.HasPrefix(o, "deprecated=") {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldKey returns the dotted key field binds to. Fields are bound by their
+// tag, see fieldTag, or, without one, by the name opts.Naming derives from
+// the field name. Unexported fields, fields tagged "-" and untagged fields
+// without a naming strategy are skipped.
+func fieldKey(field reflect.StructField, opts Options) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _ := fieldTag(field, opts)
+	switch {
+	case name == "-":
+		return "", false
+	case name != "":
+		return name, true
+	case opts.Naming != nil:
+		return opts.Naming(field.Name), true
+	}
+	return "", false
+}
+
+// squashed reports whether the fields of the struct (or pointer to struct)
+// field are promoted to the key level of its parent. That is the case for
+// embedded structs whose tag names no key and for fields tagged
+// `config:",squash"` or `config:",inline"`. Embedded unexported struct types
+// qualify too, but pointers to them cannot be reached and are skipped.
+func squashed(field reflect.StructField, opts Options) bool {
+	ft := field.Type
+	if ft.Kind() == reflect.Ptr {
+		if !field.IsExported() {
+			return false
+		}
+		ft = ft.Elem()
+	}
+	if ft.Kind() != reflect.Struct {
+		return false
+	}
+	name, options := fieldTag(field, opts)
+	if name != "" {
+		return false
+	}
+	if hasOption(options, "squash", "inline") {
+		return field.IsExported() || field.Anonymous
+	}
+	return field.Anonymous
+}
+
+// walkFields calls fn for every field of struct type t that binds to a key,
+// see fieldKey, including fields of nested structs, with the field's full
+// key path. Nested struct fields are visited after the struct field itself,
+// and fields of squashed structs are visited as fields of t. Structs that bind
+// themselves, such as yaml.Node, are not descended into, and neither are
+// struct types already being walked, so self-referential types terminate.
+func walkFields(t reflect.Type, opts Options, prefix []string, fn func(path []string, field reflect.StructField) error) error {
+	return walkFieldsIn(t, opts, prefix, fn, make(map[reflect.Type]bool))
+}
+
+// walkFieldsIn implements walkFields. active holds the struct types on the
+// current path.
+func walkFieldsIn(t reflect.Type, opts Options, prefix []string, fn func(path []string, field reflect.StructField) error, active map[reflect.Type]bool) error {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || active[t] {
+		return nil
+	}
+	active[t] = true
+	defer delete(active, t)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if squashed(field, opts) {
+			if err := walkFieldsIn(field.Type, opts, prefix, fn, active); err != nil {
+				return err
+			}
+			continue
+		}
+		key, ok := fieldKey(field, opts)
+		if !ok {
+			continue
+		}
+		path := append(append([]string{}, prefix...), strings.Split(key, ".")...)
+		if err := fn(path, field); err != nil {
+			return err
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !bindsItself(ft) {
+			if err := walkFieldsIn(ft, opts, path, fn, active); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Interpolate resolves references inside string values of data in place:
+//
+//	${db.host}           value of another config key, falling back to the
+//	                     environment variable of the same name
+//	${env:HOME}          environment variable only
+//	${db.port:-5432}     fallback used when the reference is unset or empty
+//	$${literal}          escape, produces the text "${literal}"
+//
+// A value consisting of a single reference keeps the referenced value's type.
+// Reference cycles are reported with the full chain, e.g. "a -> b -> a".
+//
+// Values of the keys in secrets are taken verbatim and never quoted in
+// errors. Interpolate returns the sorted keys whose values now contain a
+// secret value, directly or through other references.
+func Interpolate(data map[string]any, secrets []string) ([]string, error) {
+	in := &interpolator{
+		data:    data,
+		done:    make(map[string]bool),
+		secrets: secrets,
+		tainted: make(map[string]bool),
+	}
+	for _, key := range LeafKeys(data) {
+		if _, _, err := in.resolveKey(key); err != nil {
+			return nil, fmt.Errorf("interpolating %s: %w", key, err)
+		}
+	}
+	tainted := make([]string, 0, len(in.tainted))
+	for key := range in.tainted {
+		tainted = append(tainted, key)
+	}
+	sort.Strings(tainted)
+	return tainted, nil
+}
+
+type interpolator struct {
+	data    map[string]any
+	done    map[string]bool
+	stack   []string
+	secrets []string
+	// tainted holds the keys whose values were built from secret values.
+	tainted map[string]bool
+}
+
+// secret reports whether the value of key is secret, either because key is
+// listed in in.secrets or because its value references a secret.
+func (in *interpolator) secret(key string) bool {
+	if in.tainted[key] {
+		return true
+	}
+	for _, k := range in.secrets {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveKey returns the fully interpolated value of key, storing it back into data.
+func (in *interpolator) resolveKey(key string) (any, bool, error) {
+	val, ok := lookup(in.data, strings.Split(key, "."))
+	if !ok || val == nil {
+		return nil, false, nil
+	}
+	s, isStr := val.(string)
+	if !isStr || in.done[key] || in.secret(key) {
+		return val, true, nil
+	}
+	for i, k := range in.stack {
+		if k == key {
+			chain := append(append([]string{}, in.stack[i:]...), key)
+			return nil, false, fmt.Errorf("interpolation cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	in.stack = append(in.stack, key)
+	res, err := in.expand(s)
+	in.stack = in.stack[:len(in.stack)-1]
+	if err != nil {
+		return nil, false, err
+	}
+	setNested(in.data, strings.Split(key, "."), res)
+	in.done[key] = true
+	return res, true, nil
+}
+
+// expand substitutes every reference in s.
+func (in *interpolator) expand(s string) (any, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		end := matchingBrace(s, i+2)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated reference at offset %d", i)
+		}
+		val, err := in.reference(s[i+2 : end])
+		if err != nil {
+			return nil, err
+		}
+		// Keep the original type when the whole value is a single reference.
+		if i == 0 && end == len(s)-1 {
+			return val, nil
+		}
+		fmt.Fprint(&b, val)
+		i = end + 1
+	}
+	return b.String(), nil
+}
+
+// reference evaluates the expression between "${" and "}".
+func (in *interpolator) reference(expr string) (any, error) {
+	name, fallback, hasDefault := strings.Cut(expr, ":-")
+	name = strings.TrimSpace(name)
+
+	var val any
+	var found, secret bool
+	if envName, ok := strings.CutPrefix(name, "env:"); ok {
+		val, found = os.LookupEnv(envName)
+	} else {
+		var err error
+		val, found, err = in.resolveKey(name)
+		if err != nil {
+			return nil, err
+		}
+		secret = found && in.secret(name)
+		if !found {
+			val, found = os.LookupEnv(name)
+		}
+	}
+
+	if found && (val != "" || !hasDefault) {
+		if secret {
+			in.tainted[in.stack[len(in.stack)-1]] = true
+		}
+		return val, nil
+	}
+	if hasDefault {
+		return in.expand(fallback)
+	}
+	return nil, fmt.Errorf("unresolved reference ${%s}", name)
+}
+
+// matchingBrace returns the index of the "}" closing a reference whose body
+// starts at start, honoring nested "${...}" in defaults, or -1.
+func matchingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
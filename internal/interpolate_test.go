@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("INTERP_HOME", "/home/app")
+
+	data := map[string]any{
+		"db": map[string]any{
+			"user": "app",
+			"host": "${env:INTERP_DB_HOST:-localhost}",
+			"port": 5432,
+			"url":  "postgres://${db.user}@${db.host}:${db.port}/app",
+		},
+		"port":    "${db.port}",
+		"log_dir": "${INTERP_HOME}/logs",
+		"literal": "$${db.user}",
+		"timeout": "${missing:-${db.port}}",
+	}
+
+	if _, err := Interpolate(data, nil); err != nil {
+		t.Fatalf("Interpolate failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"db": map[string]any{
+			"user": "app",
+			"host": "localhost",
+			"port": 5432,
+			"url":  "postgres://app@localhost:5432/app",
+		},
+		"port":    5432,
+		"log_dir": "/home/app/logs",
+		"literal": "${db.user}",
+		"timeout": 5432,
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Interpolate mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+}
+
+func TestInterpolateCycle(t *testing.T) {
+	data := map[string]any{
+		"a": "${b}",
+		"b": "x-${c}",
+		"c": "${a}",
+	}
+
+	_, err := Interpolate(data, nil)
+	if err == nil {
+		t.Fatal("Expected cycle error, got nil")
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected error to report the cycle chain, got %v", err)
+	}
+}
+
+func TestInterpolateUnresolved(t *testing.T) {
+	data := map[string]any{"a": "${nope.nothing}"}
+	if _, err := Interpolate(data, nil); err == nil {
+		t.Error("Expected error for unresolved reference, got nil")
+	}
+}
+
+func TestInterpolateSecrets(t *testing.T) {
+	data := map[string]any{
+		"db": map[string]any{
+			"pass":  "pa$${ss",
+			"token": "x${oops",
+			"url":   "postgres://app:${db.pass}@h/db",
+		},
+		"dsn":  "${db.url}?sslmode=off",
+		"host": "${missing:-${db.host:-localhost}}",
+	}
+
+	tainted, err := Interpolate(data, []string{"db.pass", "db.token"})
+	if err != nil {
+		t.Fatalf("Interpolate failed: %v", err)
+	}
+
+	db := data["db"].(map[string]any)
+	if db["pass"] != "pa$${ss" || db["token"] != "x${oops" {
+		t.Errorf("Expected secret values to stay verbatim, got %#v", db)
+	}
+	if db["url"] != "postgres://app:pa$${ss@h/db" {
+		t.Errorf("Expected url to embed the secret, got %v", db["url"])
+	}
+	if want := []string{"db.url", "dsn"}; !reflect.DeepEqual(tainted, want) {
+		t.Errorf("Expected keys %v to reference secrets, got %v", want, tainted)
+	}
+}
+
+func TestInterpolateErrorHidesValue(t *testing.T) {
+	_, err := Interpolate(map[string]any{"a": "s3cret${oops"}, nil)
+	if err == nil {
+		t.Fatal("Expected error for unterminated reference, got nil")
+	}
+	if strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("Expected error not to quote the value, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// MaskFunc masks the string form of a secret value for display.
+type MaskFunc func(value string) string
+
+// maskers holds the masking modes usable as `secret:"<mode>"`.
+var maskers = struct {
+	sync.RWMutex
+	modes map[string]MaskFunc
+}{modes: map[string]MaskFunc{
+	"last4": maskLast4,
+	"hash":  maskHash,
+	"url":   maskURL,
+}}
+
+// RegisterMasker makes fn available as `secret:"<name>"`. Names are case
+// insensitive; registering a built-in name replaces it.
+func RegisterMasker(name string, fn MaskFunc) {
+	maskers.Lock()
+	defer maskers.Unlock()
+	maskers.modes[strings.ToLower(name)] = fn
+}
+
+// secretMode returns the masking mode for a field with the given `secret`
+// tag, or false if the field is not secret. Fields whose key was registered
+// as secret are masked completely unless the tag names a mode.
+func secretMode(tag string, secretKey bool) (string, bool) {
+	mode := strings.ToLower(strings.TrimSpace(tag))
+	switch {
+	case isTruthy(mode):
+		return "", true
+	case mode == "" || mode == "false" || mode == "0" || mode == "no" || mode == "n" || mode == "off":
+		return "", secretKey
+	}
+	return mode, true
+}
+
+// maskValue masks val with mode. Values are masked completely for the
+// default mode "" and for modes that are not registered.
+func maskValue(mode string, val any) any {
+	if mode == "" {
+		return redactedValue
+	}
+	maskers.RLock()
+	fn, ok := maskers.modes[mode]
+	maskers.RUnlock()
+	if !ok {
+		return redactedValue
+	}
+	return fn(fmt.Sprint(val))
+}
+
+// maskLast4 keeps the last four characters, e.g. "***f00d". Values of eight
+// characters or fewer are masked completely, as four would reveal too much.
+func maskLast4(value string) string {
+	r := []rune(value)
+	if len(r) <= 8 {
+		return redactedValue
+	}
+	return redactedValue + string(r[len(r)-4:])
+}
+
+// maskHash returns a short SHA-256 fingerprint, e.g. "sha256:2bb80d53",
+// to compare values without revealing them.
+func maskHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:4])
+}
+
+// maskURL masks only the credentials of a URL: the password, e.g.
+// "postgres://app:***@db:5432/app", and query parameters named like secrets,
+// e.g. "?password=***". URLs without credentials are shown unchanged. Values
+// that are not hierarchical URLs, such as "app:pass@db" which parses with
+// scheme "app", are masked completely.
+func maskURL(value string) string {
+	if masked, _, ok := maskURLCredentials(value); ok {
+		return masked
+	}
+	return redactedValue
+}
+
+// maskURLCredentials masks the password and credential query parameters of a
+// URL. It reports whether it found any and whether value is a hierarchical URL
+// at all.
+func maskURLCredentials(value string) (masked string, found, ok bool) {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Opaque != "" {
+		return "", false, false
+	}
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, p := range params {
+			name, _, ok := strings.Cut(p, "=")
+			if key, err := url.QueryUnescape(name); ok && err == nil && credentialParam(key) {
+				params[i] = name + "=" + redactedValue
+				found = true
+			}
+		}
+		u.RawQuery = strings.Join(params, "&")
+	}
+	password := false
+	if u.User != nil {
+		_, password = u.User.Password()
+	}
+	if !password {
+		return u.String(), found, true
+	}
+	u.User = url.User(u.User.Username())
+	s := u.String()
+	at := strings.Index(s, "@")
+	return s[:at] + ":" + redactedValue + s[at:], true, true
+}
+
+// credentialParam reports whether a query parameter carries a credential,
+// e.g. password, sslpassword, token or api_key.
+func credentialParam(name string) bool {
+	d := detector{patterns: DefaultSecretKeyPatterns}
+	name = strings.ToLower(name)
+	return d.secretKey(name) || strings.HasSuffix(name, "password")
+}
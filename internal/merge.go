@@ -1,17 +1,194 @@
 package internal
 
-// Merge merges two maps into a new map.
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Strategy controls how a value from a later source combines with the value
+// already merged from earlier sources.
+type Strategy string
+
+const (
+	// StrategyReplace overwrites the earlier value. It is the default for
+	// everything but maps.
+	StrategyReplace Strategy = "replace"
+	// StrategyAppend concatenates lists, earlier items first.
+	StrategyAppend Strategy = "append"
+	// StrategyDeep merges maps key by key. It is the default for maps.
+	StrategyDeep Strategy = "deep"
+	// StrategyUnion appends only list items not already present.
+	StrategyUnion Strategy = "union"
+)
+
+// Strategies maps dotted keys to the strategy used when merging them.
+type Strategies map[string]Strategy
+
+// ParseStrategy validates a strategy name such as "append".
+func ParseStrategy(s string) (Strategy, error) {
+	switch st := Strategy(strings.ToLower(strings.TrimSpace(s))); st {
+	case StrategyReplace, StrategyAppend, StrategyDeep, StrategyUnion:
+		return st, nil
+	case "deep-merge", "merge":
+		return StrategyDeep, nil
+	case "set-union":
+		return StrategyUnion, nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy %q", s)
+	}
+}
+
+// MergeOptions controls MergeWith.
+type MergeOptions struct {
+	// Strategies maps dotted keys to the strategy used when merging them.
+	Strategies Strategies
+	// FoldCase matches keys case-insensitively. The spelling merged first is kept.
+	FoldCase bool
+	// Prefix is the dotted key dst and src are found at, e.g. "db" when
+	// merging files included below db. Strategies are looked up below it.
+	Prefix string
+}
+
+// Merge merges two maps into a new map. A nil value in src replaces the value
+// in dst and is kept, so Bind sees the explicit null.
 func Merge(dst, src map[string]any) map[string]any {
-	for k, v := range src {
+	return MergeWith(dst, src, MergeOptions{})
+}
+
+// MergeWith merges src into dst like Merge, combining the keys listed in
+// opts.Strategies according to their strategy. Lists under append or union
+// are only combined when both sides are lists; otherwise src replaces dst.
+func MergeWith(dst, src map[string]any, opts MergeOptions) map[string]any {
+	strategies := opts.Strategies
+	if opts.FoldCase && len(strategies) > 0 {
+		strategies = make(Strategies, len(opts.Strategies))
+		for key, strategy := range opts.Strategies {
+			strategies[strings.ToLower(key)] = strategy
+		}
+	}
+	return mergeAt(dst, src, strategies, opts.FoldCase, opts.Prefix)
+}
+
+func mergeAt(dst, src map[string]any, strategies Strategies, fold bool, prefix string) map[string]any {
+	for sk, v := range src {
+		k := sk
+		if fold {
+			k = matchKey(dst, sk)
+		}
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		strategy := strategies[key]
+		if fold {
+			strategy = strategies[strings.ToLower(key)]
+		}
+
+		// An explicit null replaces the value set by earlier sources and is
+		// kept as a tombstone, so Bind can apply it to the field.
+		if v == nil {
+			dst[k] = nil
+			continue
+		}
+
 		if vMap, ok := v.(map[string]any); ok {
-			if dstMap, ok := dst[k].(map[string]any); ok {
-				dst[k] = Merge(dstMap, vMap)
-			} else {
-				dst[k] = Merge(make(map[string]any), vMap)
+			dstMap, ok := dst[k].(map[string]any)
+			if !ok || strategy == StrategyReplace {
+				dstMap = make(map[string]any)
+			}
+			dst[k] = mergeAt(dstMap, vMap, strategies, fold, key)
+			continue
+		}
+
+		switch strategy {
+		case StrategyAppend, StrategyUnion:
+			dstList, dstOK := dst[k].([]any)
+			srcList, srcOK := v.([]any)
+			if dstOK && srcOK {
+				dst[k] = combineLists(dstList, srcList, strategy == StrategyUnion)
+				continue
 			}
-		} else {
-			dst[k] = v
 		}
+		dst[k] = v
 	}
 	return dst
 }
+
+// matchKey returns the key of m equal to k under case folding, or k itself.
+func matchKey(m map[string]any, k string) string {
+	if _, ok := m[k]; ok {
+		return k
+	}
+	for existing := range m {
+		if strings.EqualFold(existing, k) {
+			return existing
+		}
+	}
+	return k
+}
+
+// LowercaseKeys returns a copy of data with all keys lowercased. Keys that
+// collide after lowercasing are merged.
+func LowercaseKeys(data map[string]any) map[string]any {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		if sub, ok := v.(map[string]any); ok {
+			v = LowercaseKeys(sub)
+		}
+		lk := strings.ToLower(k)
+		if existing, ok := out[lk].(map[string]any); ok {
+			if sub, ok := v.(map[string]any); ok {
+				out[lk] = Merge(existing, sub)
+				continue
+			}
+		}
+		out[lk] = v
+	}
+	return out
+}
+
+// combineLists returns a new list with the items of a followed by those of b,
+// skipping items of b already present when unique is set.
+func combineLists(a, b []any, unique bool) []any {
+	out := make([]any, 0, len(a)+len(b))
+	out = append(out, a...)
+	for _, item := range b {
+		if unique && containsValue(out, item) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func containsValue(list []any, item any) bool {
+	for _, existing := range list {
+		if reflect.DeepEqual(existing, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeStrategies collects strategies declared with `merge:"..."` tags on the
+// fields of struct type t, keyed by their dotted config key as derived with opts.
+func MergeStrategies(t reflect.Type, opts Options) (Strategies, error) {
+	out := make(Strategies)
+	err := walkFields(t, opts, nil, func(path []string, field reflect.StructField) error {
+		tag := field.Tag.Get("merge")
+		if tag == "" {
+			return nil
+		}
+		strategy, err := ParseStrategy(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		out[strings.Join(path, ".")] = strategy
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
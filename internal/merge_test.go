@@ -15,3 +15,94 @@ func TestMerge(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
+
+func TestMergeWithStrategies(t *testing.T) {
+	dst := map[string]any{
+		"hosts":  []any{"a", "b"},
+		"tags":   []any{"x", "y"},
+		"plain":  []any{1},
+		"labels": map[string]any{"team": "core", "tier": "1"},
+		"db":     map[string]any{"opts": map[string]any{"ssl": true}},
+	}
+	src := map[string]any{
+		"hosts":  []any{"c"},
+		"tags":   []any{"y", "z"},
+		"plain":  []any{2},
+		"labels": map[string]any{"team": "edge"},
+		"db":     map[string]any{"opts": map[string]any{"timeout": 5}},
+	}
+	strategies := Strategies{
+		"hosts":  StrategyAppend,
+		"tags":   StrategyUnion,
+		"labels": StrategyReplace,
+	}
+	expected := map[string]any{
+		"hosts":  []any{"a", "b", "c"},
+		"tags":   []any{"x", "y", "z"},
+		"plain":  []any{2},
+		"labels": map[string]any{"team": "edge"},
+		"db":     map[string]any{"opts": map[string]any{"ssl": true, "timeout": 5}},
+	}
+
+	result := MergeWith(dst, src, MergeOptions{Strategies: strategies})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMergeStrategies(t *testing.T) {
+	type Config struct {
+		Hosts []string `config:"hosts" merge:"append"`
+		DB    struct {
+			Tags []string `config:"tags" merge:"set-union"`
+		} `config:"db"`
+		Port int `config:"port"`
+	}
+
+	strategies, err := MergeStrategies(reflect.TypeOf(Config{}), Options{})
+	if err != nil {
+		t.Fatalf("MergeStrategies failed: %v", err)
+	}
+	expected := Strategies{"hosts": StrategyAppend, "db.tags": StrategyUnion}
+	if !reflect.DeepEqual(strategies, expected) {
+		t.Errorf("Expected %v, got %v", expected, strategies)
+	}
+
+	type Bad struct {
+		Hosts []string `config:"hosts" merge:"shuffle"`
+	}
+	if _, err := MergeStrategies(reflect.TypeOf(Bad{}), Options{}); err == nil {
+		t.Error("Expected error for unknown merge strategy, got nil")
+	}
+}
+
+func TestMergeNullKeepsTombstone(t *testing.T) {
+	dst := map[string]any{"a": 1, "b": map[string]any{"c": 2, "d": 3}, "e": map[string]any{"f": 4}}
+	src := map[string]any{"a": nil, "b": map[string]any{"c": nil}, "e": nil, "g": nil}
+	expected := map[string]any{"a": nil, "b": map[string]any{"c": nil, "d": 3}, "e": nil, "g": nil}
+
+	result := Merge(dst, src)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMergeFoldCase(t *testing.T) {
+	dst := map[string]any{"DB": map[string]any{"Host": "yaml-host", "Port": 5432}}
+	src := map[string]any{"db": map[string]any{"host": "env-host"}}
+	expected := map[string]any{"DB": map[string]any{"Host": "env-host", "Port": 5432}}
+
+	result := MergeWith(dst, src, MergeOptions{FoldCase: true})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestLowercaseKeys(t *testing.T) {
+	data := map[string]any{"DB": map[string]any{"Host": "a"}, "db": map[string]any{"port": 1}}
+	expected := map[string]any{"db": map[string]any{"host": "a", "port": 1}}
+
+	if result := LowercaseKeys(data); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a Go field name to snake_case, e.g. HTTPServer to
+// http_server and DBHost to db_host.
+func SnakeCase(name string) string {
+	return strings.Join(splitWords(name), "_")
+}
+
+// KebabCase converts a Go field name to kebab-case, e.g. MaxConns to max-conns.
+func KebabCase(name string) string {
+	return strings.Join(splitWords(name), "-")
+}
+
+// LowerCase converts a Go field name to lowercase, e.g. MaxConns to maxconns.
+func LowerCase(name string) string {
+	return strings.ToLower(name)
+}
+
+// splitWords splits a mixed-case identifier into lowercase words. A run of
+// upper case letters is an acronym, whose last letter starts the next word
+// when followed by a lower case letter: "HTTPServer" is "http", "server".
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := false
+		switch {
+		case cur == '_' || cur == '-':
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			boundary = true
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			boundary = true
+		}
+		if boundary && i > start {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return words
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// secretRefScheme is the generic envelope `secretref://<scheme>/<ref>`.
+const secretRefScheme = "secretref"
+
+// ResolveFunc resolves the reference part of a secret URI to its value.
+type ResolveFunc func(ref string) (string, error)
+
+// builtinResolvers are only reachable as `secretref://<scheme>/<ref>`, so
+// ordinary values such as `file:///var/data` are never replaced.
+var builtinResolvers = map[string]ResolveFunc{
+	"file": resolveFile,
+	"env":  resolveEnv,
+}
+
+// Resolvers is a registry of secret reference resolvers keyed by URI scheme.
+type Resolvers struct {
+	mu      sync.Mutex
+	schemes map[string]ResolveFunc
+}
+
+// NewResolvers returns a registry that resolves the built-in `file` and `env`
+// schemes through `secretref://`.
+func NewResolvers() *Resolvers {
+	return &Resolvers{schemes: make(map[string]ResolveFunc)}
+}
+
+// Register adds or replaces the resolver for scheme.
+func (r *Resolvers) Register(scheme string, fn ResolveFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemes[strings.ToLower(scheme)] = fn
+}
+
+// Resolve replaces, in place, every string value in data that references a
+// scheme as `secretref://<scheme>/<ref>` or a registered scheme as
+// `<scheme>://<ref>`. The built-in `file` resolver receives "/<ref>", an
+// absolute path; all others receive <ref>. Values with unregistered schemes,
+// such as plain https or file URLs, are left as is. Each reference is looked
+// up at most once per call, so later calls see rotated secrets.
+// It returns the sorted dotted keys whose values were resolved.
+func (r *Resolvers) Resolve(data map[string]any) ([]string, error) {
+	var resolved []string
+	if err := r.resolveMap(data, nil, make(map[string]string), &resolved); err != nil {
+		return nil, err
+	}
+	sort.Strings(resolved)
+	return resolved, nil
+}
+
+func (r *Resolvers) resolveMap(data map[string]any, prefix []string, cache map[string]string, resolved *[]string) error {
+	for k, v := range data {
+		path := append(append([]string{}, prefix...), k)
+		switch val := v.(type) {
+		case map[string]any:
+			if err := r.resolveMap(val, path, cache, resolved); err != nil {
+				return err
+			}
+		case string:
+			out, ok, err := r.resolveValue(val, cache)
+			if err != nil {
+				return fmt.Errorf("resolving %s: %w", strings.Join(path, "."), err)
+			}
+			if ok {
+				data[k] = out
+				*resolved = append(*resolved, strings.Join(path, "."))
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Resolvers) resolveValue(val string, cache map[string]string) (string, bool, error) {
+	scheme, ref, ok := strings.Cut(val, "://")
+	if !ok {
+		return "", false, nil
+	}
+	scheme = strings.ToLower(scheme)
+	generic := scheme == secretRefScheme
+	if generic {
+		var found bool
+		scheme, ref, found = strings.Cut(ref, "/")
+		if !found || scheme == "" {
+			return "", false, fmt.Errorf("malformed secret reference %q, expected secretref://<scheme>/<ref>", val)
+		}
+	}
+
+	r.mu.Lock()
+	fn, registered := r.schemes[scheme]
+	r.mu.Unlock()
+	if !registered && generic {
+		fn, registered = builtinResolvers[scheme]
+		if scheme == "file" {
+			// secretref://file/run/secrets/db names /run/secrets/db.
+			ref = "/" + ref
+		}
+	}
+	if !registered {
+		if generic {
+			return "", false, fmt.Errorf("no resolver registered for scheme %q", scheme)
+		}
+		return "", false, nil
+	}
+
+	cacheKey := scheme + "://" + ref
+	if out, hit := cache[cacheKey]; hit {
+		return out, true, nil
+	}
+	// fn runs unlocked, so slow lookups don't serialise and fn may call
+	// Register.
+	out, err := fn(ref)
+	if err != nil {
+		return "", false, fmt.Errorf("%s resolver: %w", scheme, err)
+	}
+	cache[cacheKey] = out
+	return out, true, nil
+}
+
+func resolveFile(ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("reading secret file: %w", err)
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+func resolveEnv(name string) (string, error) {
+	val, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return val, nil
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolvers(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "db")
+	if err := os.WriteFile(secretFile, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RESOLVE_TOKEN", "tkn")
+
+	calls := 0
+	r := NewResolvers()
+	r.Register("vault", func(ref string) (string, error) {
+		calls++
+		return "vault:" + ref, nil
+	})
+
+	data := map[string]any{
+		"db": map[string]any{
+			"pass": "secretref://file" + secretFile,
+			"url":  "https://example.com",
+		},
+		"token": "secretref://env/RESOLVE_TOKEN",
+		"data":  "file:///var/data",
+		"a":     "vault://kv/a",
+		"b":     "vault://kv/a",
+		"c":     "secretref://vault/kv/a",
+		"port":  8080,
+	}
+
+	keys, err := r.Resolve(data)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"db": map[string]any{
+			"pass": "hunter2",
+			"url":  "https://example.com",
+		},
+		"token": "tkn",
+		"data":  "file:///var/data",
+		"a":     "vault:kv/a",
+		"b":     "vault:kv/a",
+		"c":     "vault:kv/a",
+		"port":  8080,
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Resolve mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+	if want := []string{"a", "b", "c", "db.pass", "token"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected resolved keys %v, got %v", want, keys)
+	}
+	if calls != 1 {
+		t.Errorf("Expected cached resolver to be called once, got %d", calls)
+	}
+
+	// The cache lasts one call, so rotated secrets are picked up.
+	if _, err := r.Resolve(map[string]any{"a": "vault://kv/a"}); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected resolver to be called again by a new Resolve, got %d calls", calls)
+	}
+}
+
+func TestResolverMayRegister(t *testing.T) {
+	r := NewResolvers()
+	r.Register("outer", func(ref string) (string, error) {
+		r.Register("inner", func(ref string) (string, error) { return "inner:" + ref, nil })
+		return "outer:" + ref, nil
+	})
+	data := map[string]any{"a": "outer://x"}
+	if _, err := r.Resolve(data); err != nil || data["a"] != "outer:x" {
+		t.Fatalf("Expected outer resolver to run, got %v (%v)", data, err)
+	}
+	data = map[string]any{"b": "inner://y"}
+	if _, err := r.Resolve(data); err != nil || data["b"] != "inner:y" {
+		t.Errorf("Expected resolver registered during resolution to run, got %v (%v)", data, err)
+	}
+}
+
+func TestResolversUnknownScheme(t *testing.T) {
+	_, err := NewResolvers().Resolve(map[string]any{"a": "secretref://nope/x"})
+	if err == nil {
+		t.Error("Expected error for unregistered secretref scheme, got nil")
+	}
+}
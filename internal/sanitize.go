@@ -1,16 +1,29 @@
 package internal
 
 import (
+    "encoding"
     "encoding/json"
     "fmt"
+    "log/slog"
     "reflect"
+    "strconv"
     "strings"
+
+    "gopkg.in/yaml.v3"
 )
 
 // Sanitize traverses the target struct using `config` tags and returns a nested
-// map representation with fields marked `secret:"true"` masked out.
+// map representation with fields marked `secret:"true"` masked out. Other
+// `secret` tag values select a masking mode, see RegisterMasker.
 // The target can be a struct or a pointer to struct.
 func Sanitize(target any) (map[string]any, error) {
+    return SanitizeWith(target, MaskOptions{})
+}
+
+// SanitizeWith is like Sanitize but derives keys with opts.Fields, like
+// BindWithOptions, and also masks the keys in opts.Fields.Secrets. Values that
+// look like secrets are masked when opts.Detect is set.
+func SanitizeWith(target any, opts MaskOptions) (map[string]any, error) {
     v := reflect.ValueOf(target)
     if v.Kind() == reflect.Ptr {
         if v.IsNil() {
@@ -23,15 +36,23 @@ func Sanitize(target any) (map[string]any, error) {
     }
 
     out := make(map[string]any)
-    if err := sanitizeStruct(v, nil, out); err != nil {
+    if err := sanitizeStruct(v, opts.Fields, nil, out); err != nil {
         return nil, err
     }
+    if opts.Detect {
+        out = detectSecrets(out, opts)
+    }
     return out, nil
 }
 
 // MaskedJSON returns a JSON string representation of the sanitized struct.
 func MaskedJSON(target any) (string, error) {
-    m, err := Sanitize(target)
+    return MaskedJSONWith(target, MaskOptions{})
+}
+
+// MaskedJSONWith is like MaskedJSON but sanitizes with SanitizeWith.
+func MaskedJSONWith(target any, opts MaskOptions) (string, error) {
+    m, err := SanitizeWith(target, opts)
     if err != nil {
         return "", err
     }
@@ -42,55 +63,184 @@ func MaskedJSON(target any) (string, error) {
     return string(b), nil
 }
 
-func sanitizeStruct(v reflect.Value, prefix []string, out map[string]any) error {
+func sanitizeStruct(v reflect.Value, opts Options, prefix []string, out map[string]any) error {
     t := v.Type()
     for i := 0; i < t.NumField(); i++ {
         field := t.Field(i)
-        if !field.IsExported() {
+        if squashed(field, opts) {
+            fv := v.Field(i)
+            if fv.Kind() == reflect.Ptr {
+                if fv.IsNil() {
+                    continue
+                }
+                fv = fv.Elem()
+            }
+            if err := sanitizeStruct(fv, opts, prefix, out); err != nil {
+                return err
+            }
             continue
         }
-        tag := field.Tag.Get("config")
-        if tag == "" {
+        key, ok := fieldKey(field, opts)
+        if !ok {
             continue
         }
-        // path from tag and prefix
+        // path from key and prefix
         path := append([]string{}, prefix...)
-        path = append(path, strings.Split(tag, ".")...)
+        path = append(path, strings.Split(key, ".")...)
 
         // handle nested
         fv := v.Field(i)
         switch fv.Kind() {
         case reflect.Struct:
-            if err := sanitizeStruct(fv, path, out); err != nil {
+            if isLeafStruct(fv.Type()) {
+                break
+            }
+            if err := sanitizeStruct(fv, opts, path, out); err != nil {
                 return err
             }
             continue
         case reflect.Ptr:
-            if fv.IsNil() {
-                // nothing to add
+            if elem := fv.Type().Elem(); elem.Kind() == reflect.Struct && !isLeafStruct(elem) {
+                if fv.IsNil() {
+                    // nothing to add
+                    continue
+                }
+                if err := sanitizeStruct(fv.Elem(), opts, path, out); err != nil {
+                    return err
+                }
                 continue
             }
-            if fv.Elem().Kind() == reflect.Struct {
-                if err := sanitizeStruct(fv.Elem(), path, out); err != nil {
+            // Pointers to scalars, slices and maps are shown by value, or as
+            // nil when the key was not configured.
+            for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+                fv = fv.Elem()
+            }
+        }
+
+        // Slices and maps of structs not tagged secret as a whole are shown
+        // element by element, so the elements' own secrets are masked.
+        if _, tagged := secretMode(field.Tag.Get("secret"), false); !tagged {
+            if elems, ok, err := sanitizeElems(fv, opts, path); ok || err != nil {
+                if err != nil {
                     return err
                 }
+                setNested(out, path, elems)
                 continue
             }
         }
 
         // leaf value
-        masked := field.Tag.Get("secret")
-        if isTruthy(masked) {
-            setNested(out, path, "***")
+        if mode, ok := secretMode(field.Tag.Get("secret"), opts.secretKey(strings.Join(path, "."))); ok {
+            if mode == "" || fv.Kind() == reflect.Ptr && fv.IsNil() {
+                setNested(out, path, redactedValue)
+            } else {
+                setNested(out, path, maskValue(mode, fv.Interface()))
+            }
             continue
         }
 
         // Use the field's current value for non-secret entries
+        if fv.Kind() == reflect.Ptr && fv.IsNil() {
+            setNested(out, path, nil)
+            continue
+        }
+        // YAML nodes are shown as the subtree they hold.
+        if node, ok := fv.Interface().(yaml.Node); ok {
+            var decoded any
+            if err := node.Decode(&decoded); err != nil {
+                return fmt.Errorf("decoding %s: %w", strings.Join(path, "."), err)
+            }
+            setNested(out, path, decoded)
+            continue
+        }
+        // Types that know how to log themselves, such as goconfig.Secret,
+        // are shown the way they log.
+        if lv, ok := fv.Interface().(slog.LogValuer); ok {
+            setNested(out, path, lv.LogValue().Resolve().Any())
+            continue
+        }
         setNested(out, path, fv.Interface())
     }
     return nil
 }
 
+// sanitizeElems sanitizes the struct elements of slice, array or map v found
+// at key path, keyed by index or map key like their bind errors. It reports
+// false if the elements are not structs or pointers to structs.
+func sanitizeElems(v reflect.Value, opts Options, path []string) (any, bool, error) {
+    switch v.Kind() {
+    case reflect.Slice, reflect.Array, reflect.Map:
+    default:
+        return nil, false, nil
+    }
+    et := v.Type().Elem()
+    if et.Kind() == reflect.Ptr {
+        et = et.Elem()
+    }
+    if et.Kind() != reflect.Struct || isLeafStruct(et) {
+        return nil, false, nil
+    }
+    if v.Kind() == reflect.Map {
+        if v.IsNil() {
+            return nil, true, nil
+        }
+        out := make(map[string]any, v.Len())
+        iter := v.MapRange()
+        for iter.Next() {
+            k := fmt.Sprint(iter.Key().Interface())
+            elem, err := sanitizeElem(iter.Value(), opts, append(append([]string{}, path...), k))
+            if err != nil {
+                return nil, true, err
+            }
+            out[k] = elem
+        }
+        return out, true, nil
+    }
+    if v.Kind() == reflect.Slice && v.IsNil() {
+        return nil, true, nil
+    }
+    out := make([]any, v.Len())
+    for i := range v.Len() {
+        elem, err := sanitizeElem(v.Index(i), opts, append(append([]string{}, path...), strconv.Itoa(i)))
+        if err != nil {
+            return nil, true, err
+        }
+        out[i] = elem
+    }
+    return out, true, nil
+}
+
+// sanitizeElem sanitizes the struct, or pointer to struct, v found at key path.
+func sanitizeElem(v reflect.Value, opts Options, path []string) (any, error) {
+    if v.Kind() == reflect.Ptr {
+        if v.IsNil() {
+            return nil, nil
+        }
+        v = v.Elem()
+    }
+    // Fields are sanitized under their full path, so secret keys below path
+    // apply, and then taken out again.
+    tmp := make(map[string]any)
+    if err := sanitizeStruct(v, opts, path, tmp); err != nil {
+        return nil, err
+    }
+    if m, ok := lookup(tmp, path); ok {
+        return m, nil
+    }
+    return map[string]any{}, nil
+}
+
+// isLeafStruct reports whether struct type t is shown as a single value
+// rather than by its fields, because it binds or renders itself, like
+// time.Time or goconfig.Secret.
+func isLeafStruct(t reflect.Type) bool {
+    pt := reflect.PointerTo(t)
+    return t == yamlNodeType || pt.Implements(binderType) ||
+        t.Implements(reflect.TypeFor[encoding.TextMarshaler]()) ||
+        t.Implements(reflect.TypeFor[json.Marshaler]()) ||
+        t.Implements(reflect.TypeFor[slog.LogValuer]())
+}
+
 func setNested(dst map[string]any, keys []string, val any) {
     if len(keys) == 0 {
         return
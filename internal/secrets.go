@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// secretKey reports whether key or a key below it is listed in o.Secrets, so
+// map and interface fields holding secret values are masked as a whole. Keys
+// are matched case-insensitively, so masking holds under any key case mode.
+func (o Options) secretKey(key string) bool {
+	for _, k := range o.Secrets {
+		if len(k) > len(key) && k[len(key)] == '.' {
+			k = k[:len(key)]
+		}
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeafKeys returns the sorted dotted paths of all non-map values in data.
+func LeafKeys(data map[string]any) []string {
+	var out []string
+	collectLeafKeys(data, nil, &out)
+	sort.Strings(out)
+	return out
+}
+
+func collectLeafKeys(data map[string]any, prefix []string, out *[]string) {
+	for k, v := range data {
+		path := append(append([]string{}, prefix...), k)
+		if sub, ok := v.(map[string]any); ok && len(sub) > 0 {
+			collectLeafKeys(sub, path, out)
+			continue
+		}
+		*out = append(*out, strings.Join(path, "."))
+	}
+}
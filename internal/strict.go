@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownKeys returns the sorted dotted keys of data that no field of struct
+// type t binds to. Keys below fields holding maps, slices, interfaces or
+// Binder types are accepted, since those fields take arbitrary content, and
+// so are keys below fields of self-referential types, which nest without limit.
+// With opts.FoldCase keys are compared case-insensitively.
+func UnknownKeys(data map[string]any, t reflect.Type, opts Options) []string {
+	norm := func(s string) string { return s }
+	if opts.FoldCase {
+		norm = strings.ToLower
+	}
+
+	known := make(map[string]bool)
+	var open []string
+	// structs maps the keys of struct fields to their type, to detect fields
+	// whose type encloses them.
+	root := t
+	for root != nil && root.Kind() == reflect.Ptr {
+		root = root.Elem()
+	}
+	structs := map[string]reflect.Type{"": root}
+	_ = walkFields(t, opts, nil, func(path []string, field reflect.StructField) error {
+		key := norm(strings.Join(path, "."))
+		known[key] = true
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Map, ft.Kind() == reflect.Slice, ft.Kind() == reflect.Array, ft.Kind() == reflect.Interface,
+			ft == yamlNodeType, reflect.PointerTo(ft).Implements(binderType):
+			open = append(open, key+".")
+		case ft.Kind() == reflect.Struct:
+			for i := range path {
+				if structs[norm(strings.Join(path[:i], "."))] == ft {
+					open = append(open, key+".")
+					break
+				}
+			}
+			structs[key] = ft
+		}
+		return nil
+	})
+
+	var unknown []string
+	for _, key := range LeafKeys(data) {
+		if known[norm(key)] || hasAnyPrefix(norm(key), open) {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
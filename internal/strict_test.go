@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	type Config struct {
+		DB struct {
+			Host string `config:"host"`
+			Port int    `config:"port"`
+		} `config:"db"`
+		Labels map[string]string `config:"labels"`
+		Port   int               `config:"server.port"`
+	}
+
+	data := map[string]any{
+		"db": map[string]any{
+			"host": "localhost",
+			"hots": "typo",
+		},
+		"labels": map[string]any{"anything": "goes"},
+		"server": map[string]any{"port": 80, "name": "x"},
+		"extra":  1,
+	}
+
+	unknown := UnknownKeys(data, reflect.TypeOf(Config{}), Options{})
+	expected := []string{"db.hots", "extra", "server.name"}
+	if !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("Expected unknown keys %v, got %v", expected, unknown)
+	}
+}
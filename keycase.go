@@ -0,0 +1,35 @@
+package goconfig
+
+import "github.com/shkmv/goconfig/internal"
+
+// KeyCase controls how key spelling is unified across sources. EnvSource and
+// DotEnvSource lowercase keys while FileSource preserves them as written.
+type KeyCase int
+
+const (
+	// KeyCaseStrict matches keys exactly as written. This is the default.
+	KeyCaseStrict KeyCase = iota
+	// KeyCaseLower lowercases the keys of every source before merging and
+	// matches `config` tags case-insensitively.
+	KeyCaseLower
+	// KeyCaseInsensitive merges and looks up keys case-insensitively while
+	// keeping the spelling of the first source that set them.
+	KeyCaseInsensitive
+)
+
+// KeyCase sets how keys from different sources are unified, e.g.
+// KeyCaseInsensitive lets APP_DB_HOST override `DB.Host` from YAML.
+func (c *Config) KeyCase(mode KeyCase) *Config {
+	c.keyCase = mode
+	return c
+}
+
+// bindOptions returns the internal.Options matching the configuration.
+func (c *Config) bindOptions() internal.Options {
+	return internal.Options{
+		ZeroNull: c.zeroNull,
+		FoldCase: c.keyCase != KeyCaseStrict,
+		Naming:   c.naming,
+		TagNames: c.tagNames,
+	}
+}
@@ -5,6 +5,18 @@ package goconfig
 //
 //	cfg := Load[Config](WithEnv("APP"))
 func Load[T any](opts ...Option) (T, error) {
+	target, _, err := LoadConfig[T](opts...)
+	return target, err
+}
+
+// LoadConfig is like Load but also returns the Config it bound with, whose
+// MaskedMap and MaskedJSON methods mask values from secret sources such as
+// WithCredentials, and whose UsedFiles reports the files loaded.
+// example:
+//
+//	cfg, c, err := LoadConfig[Config](WithFile("config.yaml"), WithCredentials())
+//	safe, _ := c.MaskedJSON(cfg)
+func LoadConfig[T any](opts ...Option) (T, *Config, error) {
 	var zero T
 
 	cfg := New()
@@ -14,8 +26,8 @@ func Load[T any](opts ...Option) (T, error) {
 
 	var target T
 	if err := cfg.Bind(&target); err != nil {
-		return zero, err
+		return zero, cfg, err
 	}
 
-	return target, nil
+	return target, cfg, nil
 }
@@ -1,18 +1,135 @@
 package goconfig
 
 import (
+    "reflect"
+
     "github.com/shkmv/goconfig/internal"
 )
 
+// MaskOption configures MaskedMap and MaskedJSON.
+type MaskOption func(*internal.MaskOptions)
+
+// WithSecretDetection additionally masks untagged values whose key contains
+// a word like password, secret, token, key, credential or dsn, and values
+// that look like credentials: URLs with a password (only the password is
+// masked), JWTs and PEM blocks.
+func WithSecretDetection() MaskOption {
+    return func(o *internal.MaskOptions) {
+        o.Detect = true
+    }
+}
+
+// WithSecretKeyPatterns enables secret detection with patterns replacing
+// the default key words, e.g. WithSecretKeyPatterns("password", "pin").
+func WithSecretKeyPatterns(patterns ...string) MaskOption {
+    return func(o *internal.MaskOptions) {
+        o.Detect = true
+        o.KeyPatterns = patterns
+    }
+}
+
+// WithAllowedKeys exempts keys, e.g. "cache.key_prefix" or "*.token_ttl",
+// and everything below them from secret detection. Tagged fields are still
+// masked.
+func WithAllowedKeys(keys ...string) MaskOption {
+    return func(o *internal.MaskOptions) {
+        o.Allow = append(o.Allow, keys...)
+    }
+}
+
+// WithMaskNaming derives the keys of untagged fields with strategy, like
+// Config.Naming does when binding.
+func WithMaskNaming(strategy NamingStrategy) MaskOption {
+    return func(o *internal.MaskOptions) {
+        o.Fields.Naming = strategy
+    }
+}
+
+// WithMaskTagNames reads keys from the given struct tags, like
+// Config.TagNames does when binding.
+func WithMaskTagNames(names ...string) MaskOption {
+    return func(o *internal.MaskOptions) {
+        o.Fields.TagNames = names
+    }
+}
+
+func maskOptions(opts []MaskOption) internal.MaskOptions {
+    return applyMaskOptions(internal.MaskOptions{}, opts)
+}
+
+func applyMaskOptions(o internal.MaskOptions, opts []MaskOption) internal.MaskOptions {
+    for _, opt := range opts {
+        opt(&o)
+    }
+    return o
+}
+
 // MaskedMap returns a nested map representation of the target struct with
-// fields tagged `secret:"true"` masked.
-func MaskedMap(target any) (map[string]any, error) {
-    return internal.Sanitize(target)
+// fields tagged `secret:"true"` masked. `secret:"last4"`, `secret:"hash"` and
+// `secret:"url"` mask partially, see RegisterMasker. Pass
+// WithSecretDetection to also mask untagged values that look like secrets.
+func MaskedMap(target any, opts ...MaskOption) (map[string]any, error) {
+    return internal.SanitizeWith(target, maskOptions(opts))
 }
 
 // MaskedJSON returns a JSON string representation of the target struct with
 // fields tagged `secret:"true"` masked. Suitable for safe logging.
-func MaskedJSON(target any) (string, error) {
-    return internal.MaskedJSON(target)
+func MaskedJSON(target any, opts ...MaskOption) (string, error) {
+    return internal.MaskedJSONWith(target, maskOptions(opts))
+}
+
+// MaskedMap is like the package-level MaskedMap but derives keys with the
+// naming strategy and tag names of c, so the output matches what Bind reads.
+// Values that the last c.Bind of the target's type took from secret sources,
+// such as credentials, resolved references or decrypted envelopes, are
+// masked as well.
+func (c *Config) MaskedMap(target any, opts ...MaskOption) (map[string]any, error) {
+    return internal.SanitizeWith(target, c.maskOptions(target, opts))
+}
+
+// MaskedJSON is like the package-level MaskedJSON but masks and derives keys
+// like Config.MaskedMap.
+func (c *Config) MaskedJSON(target any, opts ...MaskOption) (string, error) {
+    return internal.MaskedJSONWith(target, c.maskOptions(target, opts))
+}
+
+func (c *Config) maskOptions(target any, opts []MaskOption) internal.MaskOptions {
+    fields := c.bindOptions()
+    fields.Secrets = c.secretKeys(target)
+    return applyMaskOptions(internal.MaskOptions{Fields: fields}, opts)
 }
 
+// recordSecrets replaces the secret keys recorded for the type of target.
+func (c *Config) recordSecrets(target any, keys []string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.secrets == nil {
+        c.secrets = make(map[reflect.Type][]string)
+    }
+    c.secrets[targetType(target)] = keys
+}
+
+// secretKeys returns the secret keys recorded for the type of target.
+func (c *Config) secretKeys(target any) []string {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.secrets[targetType(target)]
+}
+
+// targetType returns the struct type behind target, so a struct and a
+// pointer to it share their secret keys.
+func targetType(target any) reflect.Type {
+    t := reflect.TypeOf(target)
+    for t != nil && t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    return t
+}
+
+// RegisterMasker makes fn available as a masking mode for the `secret` tag,
+// e.g. RegisterMasker("prefix", ...) for `secret:"prefix"`. fn receives the
+// field value formatted as a string. Built-in modes are "true" (mask
+// completely), "last4", "hash" and "url".
+func RegisterMasker(name string, fn func(value string) string) {
+    internal.RegisterMasker(name, fn)
+}
@@ -0,0 +1,18 @@
+package goconfig
+
+// MergeStrategy controls how values for the same key from successive sources
+// combine. It can be declared on a field with a `merge:"..."` tag or
+// registered for a dotted key with Config.MergeStrategy.
+type MergeStrategy string
+
+const (
+	// MergeReplace lets a later source overwrite the value. It is the default
+	// for everything but maps.
+	MergeReplace MergeStrategy = "replace"
+	// MergeAppend concatenates lists, earlier sources first.
+	MergeAppend MergeStrategy = "append"
+	// MergeDeep merges maps key by key. It is the default for maps.
+	MergeDeep MergeStrategy = "deep"
+	// MergeUnion appends only list items not already present.
+	MergeUnion MergeStrategy = "union"
+)
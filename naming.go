@@ -0,0 +1,35 @@
+package goconfig
+
+import "github.com/shkmv/goconfig/internal"
+
+// NamingStrategy derives the configuration key of a struct field without a
+// `config` tag from its Go field name.
+type NamingStrategy func(field string) string
+
+var (
+	// SnakeCase names fields in snake_case, e.g. MaxConns as max_conns and
+	// HTTPServer as http_server.
+	SnakeCase NamingStrategy = internal.SnakeCase
+	// KebabCase names fields in kebab-case, e.g. MaxConns as max-conns.
+	KebabCase NamingStrategy = internal.KebabCase
+	// LowerCase names fields in lowercase, e.g. MaxConns as maxconns.
+	LowerCase NamingStrategy = internal.LowerCase
+)
+
+// Naming binds exported fields without a `config` tag to the key strategy
+// derives from the field name. Fields tagged `config:"-"` are always skipped.
+// Config.MaskedMap and Config.MaskedJSON use the same keys.
+func (c *Config) Naming(strategy NamingStrategy) *Config {
+	c.naming = strategy
+	return c
+}
+
+// TagNames sets the struct tags keys are read from, in order of precedence,
+// e.g. TagNames("config", "yaml", "mapstructure") to reuse the tags of structs
+// written for other libraries. Tag options such as `omitempty` are ignored.
+// Names in `env` tags are mapped like EnvSource maps variables without its
+// prefix, so `env:"DB_HOST"` binds db.host. It defaults to "config".
+func (c *Config) TagNames(names ...string) *Config {
+	c.tagNames = names
+	return c
+}
@@ -25,3 +25,179 @@ func WithDotEnv(path string) Option {
         c.sources = append(c.sources, sources.NewDotEnvSource(path))
     }
 }
+
+// WithAgeFile adds an age-encrypted file source decrypted with the
+// identities stored in identityFile.
+func WithAgeFile(path, identityFile string) Option {
+	return func(c *Config) {
+		c.sources = append(c.sources, sources.NewAgeFileSource(path).WithIdentityFile(identityFile))
+	}
+}
+
+// WithDir adds a directory-of-files source (one key per file) to the configuration.
+func WithDir(path string) Option {
+	return func(c *Config) {
+		c.sources = append(c.sources, sources.NewDirSource(path))
+	}
+}
+
+// WithCredentials adds a systemd credentials source ($CREDENTIALS_DIRECTORY)
+// to the configuration.
+func WithCredentials() Option {
+	return func(c *Config) {
+		c.sources = append(c.sources, sources.NewCredentialsSource())
+	}
+}
+
+// WithConfDir adds a source merging all fragments of a conf.d style directory.
+func WithConfDir(dir string) Option {
+	return func(c *Config) {
+		c.FromConfDir(dir)
+	}
+}
+
+// WithDiscovery adds a source loading the first `<name>.yaml|yml|json` found
+// in the conventional configuration directories for app.
+func WithDiscovery(app, name string) Option {
+	return func(c *Config) {
+		c.FromDiscovery(app, name)
+	}
+}
+
+// WithDiscoveryAll adds a source loading and merging every `<name>.yaml|yml|json`
+// found in the conventional configuration directories for app.
+func WithDiscoveryAll(app, name string) Option {
+	return func(c *Config) {
+		c.FromDiscoveryAll(app, name)
+	}
+}
+
+// WithSource adds a custom or preconfigured source to the configuration.
+func WithSource(src sources.Source) Option {
+	return func(c *Config) {
+		c.sources = append(c.sources, src)
+	}
+}
+
+// WithInterpolation enables `${...}` reference resolution in configuration values.
+func WithInterpolation() Option {
+	return func(c *Config) {
+		c.interpolate = true
+	}
+}
+
+// WithSecretResolution enables resolution of `secretref://file/...` and
+// `secretref://env/...` secret references in configuration values.
+func WithSecretResolution() Option {
+	return func(c *Config) {
+		c.ResolveSecrets()
+	}
+}
+
+// WithResolver registers a secret reference resolver for the given URI scheme.
+func WithResolver(scheme string, fn ResolverFunc) Option {
+	return func(c *Config) {
+		c.Resolver(scheme, fn)
+	}
+}
+
+// WithDecryptionKey enables decryption of ENC[...] envelopes using key.
+func WithDecryptionKey(key []byte) Option {
+	return func(c *Config) {
+		c.Decrypt(key)
+	}
+}
+
+// WithDecryptionKeyFile enables decryption of ENC[...] envelopes using the key stored at path.
+func WithDecryptionKeyFile(path string) Option {
+	return func(c *Config) {
+		c.DecryptWithKeyFile(path)
+	}
+}
+
+// WithDecryptionKeyEnv enables decryption of ENC[...] envelopes using the key
+// stored in the environment variable name.
+func WithDecryptionKeyEnv(name string) Option {
+	return func(c *Config) {
+		c.DecryptWithKeyEnv(name)
+	}
+}
+
+// WithProfile activates a configuration profile such as "production".
+func WithProfile(profile string) Option {
+	return func(c *Config) {
+		c.SetProfile(profile)
+	}
+}
+
+// WithProfileEnv selects the active profile from the environment variable name.
+func WithProfileEnv(name string) Option {
+	return func(c *Config) {
+		c.ProfileFromEnv(name)
+	}
+}
+
+// WithMergeStrategy sets how values for the dotted key from successive sources combine.
+func WithMergeStrategy(key string, strategy MergeStrategy) Option {
+	return func(c *Config) {
+		c.MergeStrategy(key, strategy)
+	}
+}
+
+// WithZeroNull makes an explicit null zero non-nilable fields instead of
+// keeping their default.
+func WithZeroNull() Option {
+	return func(c *Config) {
+		c.ZeroNull()
+	}
+}
+
+// WithStrict makes loading fail on keys that no field of the target refers to.
+func WithStrict() Option {
+	return func(c *Config) {
+		c.Strict()
+	}
+}
+
+// WithUnknownKeyHandler reports keys unknown to the target to fn instead of failing.
+func WithUnknownKeyHandler(fn func(key, source string)) Option {
+	return func(c *Config) {
+		c.OnUnknownKey(fn)
+	}
+}
+
+// WithLenient excludes the source added by the preceding option from strict
+// key checking.
+func WithLenient() Option {
+	return func(c *Config) {
+		c.Lenient()
+	}
+}
+
+// WithKeyCase sets how keys from different sources are unified.
+func WithKeyCase(mode KeyCase) Option {
+	return func(c *Config) {
+		c.KeyCase(mode)
+	}
+}
+
+// WithNaming derives keys of untagged fields from their names using strategy.
+func WithNaming(strategy NamingStrategy) Option {
+	return func(c *Config) {
+		c.Naming(strategy)
+	}
+}
+
+// WithTagNames sets the struct tags keys are read from, in order of precedence.
+func WithTagNames(names ...string) Option {
+	return func(c *Config) {
+		c.TagNames(names...)
+	}
+}
+
+// WithDeprecationHandler sets fn to receive warnings about deprecated keys.
+func WithDeprecationHandler(fn func(old, key, source string)) Option {
+	return func(c *Config) {
+		c.OnDeprecatedKey(fn)
+	}
+}
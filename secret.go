@@ -0,0 +1,74 @@
+package goconfig
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// redacted replaces secret values wherever they are printed.
+const redacted = "***"
+
+// Secret holds a configuration value that must not leak into logs. Printing
+// it with fmt (including %+v and %#v), encoding it as JSON or text, or
+// logging it with slog all yield "***"; only Reveal returns the value.
+//
+//	type DBConfig struct {
+//		Password goconfig.Secret[string] `config:"password"`
+//	}
+//
+// Secret fields bind like fields of type T.
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret wraps value in a Secret.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Reveal returns the secret value.
+func (s Secret[T]) Reveal() T {
+	return s.value
+}
+
+// String implements fmt.Stringer and returns "***".
+func (s Secret[T]) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer and returns "***".
+func (s Secret[T]) GoString() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter, redacting the value for every verb.
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+// MarshalJSON implements json.Marshaler and returns "***" as a JSON string.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns "***".
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue implements slog.LogValuer and logs "***".
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// IsSecret reports true, so that Bind masks the value in errors.
+func (s Secret[T]) IsSecret() bool {
+	return true
+}
+
+// BindConfig binds the configured value to the wrapped value.
+// It is called by Bind and not meant to be used directly.
+func (s *Secret[T]) BindConfig(bind func(target any) error) error {
+	return bind(&s.value)
+}
@@ -0,0 +1,91 @@
+package goconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretRedacts(t *testing.T) {
+	type Creds struct {
+		User     string         `config:"user"`
+		Password Secret[string] `config:"password"`
+	}
+	c := Creds{User: "admin", Password: NewSecret("hunter2")}
+
+	outputs := []string{
+		c.Password.String(),
+		fmt.Sprint(c.Password),
+		fmt.Sprintf("%v %+v %#v %s %q %x %d", c, c, c, c.Password, c.Password, c.Password, c.Password),
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	outputs = append(outputs, string(b))
+	text, _ := c.Password.MarshalText()
+	outputs = append(outputs, string(text))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("loaded", "creds", c, "password", c.Password)
+	outputs = append(outputs, buf.String())
+
+	for _, out := range outputs {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("Secret leaked: %s", out)
+		}
+	}
+	if !strings.Contains(string(b), `"Password":"***"`) {
+		t.Errorf("Expected redacted JSON, got %s", b)
+	}
+	if c.Password.Reveal() != "hunter2" {
+		t.Errorf("Expected Reveal to return the value, got %q", c.Password.Reveal())
+	}
+}
+
+func TestSecretBinds(t *testing.T) {
+	type DB struct {
+		Host string `config:"host"`
+		Pass string `config:"pass"`
+	}
+	type AppConfig struct {
+		Password Secret[string]  `config:"password"`
+		Port     Secret[int]     `config:"port"`
+		Ptr      *Secret[string] `config:"ptr"`
+		DB       Secret[DB]      `config:"db"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte("password: hunter2\nptr: token\ndb:\n  host: localhost\n  pass: pw\n"), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	t.Setenv("SECRETAPP_PORT", "5432")
+
+	cfg, err := Load[AppConfig](WithFile(yamlPath), WithEnv("SECRETAPP_"), WithStrict())
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Password.Reveal() != "hunter2" || cfg.Port.Reveal() != 5432 || cfg.Ptr == nil || cfg.Ptr.Reveal() != "token" {
+		t.Errorf("Unexpected values: %v %v %v", cfg.Password.Reveal(), cfg.Port.Reveal(), cfg.Ptr)
+	}
+	if db := cfg.DB.Reveal(); db.Host != "localhost" || db.Pass != "pw" {
+		t.Errorf("Unexpected db: %+v", db)
+	}
+
+	masked, err := MaskedMap(&cfg)
+	if err != nil {
+		t.Fatalf("MaskedMap failed: %v", err)
+	}
+	for _, key := range []string{"password", "port", "ptr", "db"} {
+		if masked[key] != "***" {
+			t.Errorf("Expected %s to be masked, got %#v", key, masked[key])
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package sources
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+func writeAgeFile(t *testing.T, path string, recipient age.Recipient, plaintext string, armored bool) {
+	t.Helper()
+	var out bytes.Buffer
+	var dst io.Writer = &out
+	var aw io.WriteCloser
+	if armored {
+		aw = armor.NewWriter(&out)
+		dst = aw
+	}
+	w, err := age.Encrypt(dst, recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if aw != nil {
+		if err := aw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAgeFileSource_Load(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tempDir := t.TempDir()
+	identityFile := filepath.Join(tempDir, "key.txt")
+	if err := os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, armored := range []bool{false, true} {
+		path := filepath.Join(tempDir, "config.yaml.age")
+		writeAgeFile(t, path, identity.Recipient(), "db:\n  pass: hunter2\nport: 3000\n", armored)
+
+		data, err := NewAgeFileSource(path).WithIdentityFile(identityFile).Load()
+		if err != nil {
+			t.Fatalf("Load (armored=%v) failed: %v", armored, err)
+		}
+		if db, _ := data["db"].(map[string]any); db["pass"] != "hunter2" {
+			t.Errorf("expected db.pass to be hunter2 (armored=%v), got %v", armored, data)
+		}
+		if data["port"] != 3000 {
+			t.Errorf("expected port to be 3000 (armored=%v), got %v", armored, data["port"])
+		}
+	}
+}
+
+func TestAgeFileSource_IdentityEnv(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml.age")
+	writeAgeFile(t, path, identity.Recipient(), "port: 3000\n", false)
+	t.Setenv("TEST_AGE_IDENTITY", identity.String())
+
+	data, err := NewAgeFileSource(path).WithIdentityEnv("TEST_AGE_IDENTITY").Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if data["port"] != 3000 {
+		t.Errorf("expected port to be 3000, got %v", data["port"])
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_AGE_IDENTITY", other.String())
+	if _, err := NewAgeFileSource(path).WithIdentityEnv("TEST_AGE_IDENTITY").Load(); err == nil {
+		t.Error("expected error decrypting with a non-matching identity, got nil")
+	}
+}
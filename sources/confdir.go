@@ -0,0 +1,105 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+// ConfDirSource loads every configuration fragment in a conf.d style
+// directory, such as /etc/app/conf.d, merging them in lexical order so later
+// fragments override earlier ones (10-base.yaml < 50-local.yaml).
+type ConfDirSource struct {
+	dir        string
+	pattern    string
+	skipHidden bool
+	merge      internal.MergeOptions
+	used       []string
+}
+
+// NewConfDirSource creates a ConfDirSource for dir. By default it loads all
+// files with one of the SupportedExtensions and skips hidden files.
+func NewConfDirSource(dir string) *ConfDirSource {
+	return &ConfDirSource{dir: dir, skipHidden: true}
+}
+
+// String describes the source for error messages.
+func (c *ConfDirSource) String() string {
+	return "conf.d " + c.dir
+}
+
+// WithPattern restricts fragments to file names matching the glob pattern,
+// e.g. "*.yaml".
+func (c *ConfDirSource) WithPattern(pattern string) *ConfDirSource {
+	c.pattern = pattern
+	return c
+}
+
+// IncludeHidden also loads fragments whose names start with ".".
+func (c *ConfDirSource) IncludeHidden() *ConfDirSource {
+	c.skipHidden = false
+	return c
+}
+
+// SetMergeOptions sets how fragments are merged. Config.Bind passes the
+// merge strategies of the target, so they apply between fragments too.
+func (c *ConfDirSource) SetMergeOptions(opts internal.MergeOptions) {
+	c.merge = opts
+}
+
+// Used returns the fragments loaded by the last call to Load, in merge order.
+func (c *ConfDirSource) Used() []string {
+	return append([]string{}, c.used...)
+}
+
+// Load merges all matching fragments. A missing directory yields an empty map.
+// Errors name the fragment that caused them.
+func (c *ConfDirSource) Load() (map[string]any, error) {
+	c.used = c.used[:0]
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %s: %w", c.dir, err)
+	}
+
+	out := make(map[string]any)
+	// os.ReadDir returns entries sorted by name, which gives lexical order.
+	for _, entry := range entries {
+		name := entry.Name()
+		if !c.matches(name) {
+			continue
+		}
+		path := filepath.Join(c.dir, name)
+		if !fileExists(path) {
+			continue
+		}
+		fragment := NewFileSource(path)
+		fragment.SetMergeOptions(c.merge)
+		data, err := fragment.Load()
+		if err != nil {
+			return nil, fmt.Errorf("conf.d fragment %s: %w", path, err)
+		}
+		out = internal.MergeWith(out, data, c.merge)
+		c.used = append(c.used, path)
+	}
+	return out, nil
+}
+
+func (c *ConfDirSource) matches(name string) bool {
+	if c.skipHidden && strings.HasPrefix(name, ".") {
+		return false
+	}
+	if c.pattern != "" {
+		ok, err := filepath.Match(c.pattern, name)
+		return err == nil && ok
+	}
+	return slices.Contains(SupportedExtensions, filepath.Ext(name))
+}
@@ -0,0 +1,64 @@
+package sources
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfDirSource(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"10-base.yaml":    "port: 80\nlog:\n  level: info\n",
+		"50-db.yml":       "db:\n  host: localhost\n",
+		"90-local.json":   `{"port": 3000}`,
+		".99-hidden.yaml": "port: 1\n",
+		"README":          "not config",
+	})
+
+	src := NewConfDirSource(dir)
+	data, err := src.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	expected := map[string]any{
+		"port": 3000,
+		"log":  map[string]any{"level": "info"},
+		"db":   map[string]any{"host": "localhost"},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Load mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+	if len(src.Used()) != 3 {
+		t.Errorf("expected 3 fragments used, got %v", src.Used())
+	}
+
+	data, err = NewConfDirSource(dir).WithPattern("*.yaml").IncludeHidden().Load()
+	if err != nil {
+		t.Fatalf("Load with pattern failed: %v", err)
+	}
+	if data["port"] != 80 {
+		t.Errorf("expected port from 10-base.yaml (hidden sorts first), got %v", data["port"])
+	}
+}
+
+func TestConfDirSource_FragmentError(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"10-ok.yaml":     "port: 80\n",
+		"20-broken.yaml": "this is: [not valid",
+	})
+
+	_, err := NewConfDirSource(dir).Load()
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(dir, "20-broken.yaml")) {
+		t.Errorf("expected error naming the broken fragment, got %v", err)
+	}
+}
+
+func TestConfDirSource_MissingDir(t *testing.T) {
+	data, err := NewConfDirSource(filepath.Join(t.TempDir(), "conf.d")).Load()
+	if err != nil || len(data) != 0 {
+		t.Errorf("expected empty map for missing directory, got %v, %v", data, err)
+	}
+}
@@ -0,0 +1,44 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+)
+
+// CredentialsDirectoryEnv is the variable systemd sets to the directory holding
+// credentials passed via LoadCredential= and SetCredential=.
+const CredentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+
+// CredentialsSource loads systemd service credentials from $CREDENTIALS_DIRECTORY.
+// Each credential name is a key split on "." (e.g. `db.pass`), and every value
+// it loads is treated as secret.
+type CredentialsSource struct{}
+
+// NewCredentialsSource creates a new CredentialsSource.
+func NewCredentialsSource() *CredentialsSource {
+	return &CredentialsSource{}
+}
+
+// String describes the source for error messages.
+func (s *CredentialsSource) String() string {
+	return "systemd credentials"
+}
+
+// Load reads all credentials from $CREDENTIALS_DIRECTORY. When the variable is
+// unset (the service was not started with credentials) it returns an empty map.
+func (s *CredentialsSource) Load() (map[string]any, error) {
+	dir := os.Getenv(CredentialsDirectoryEnv)
+	if dir == "" {
+		return map[string]any{}, nil
+	}
+	out, err := NewDirSource(dir).Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading systemd credentials: %w", err)
+	}
+	return out, nil
+}
+
+// Secret reports that all credentials are sensitive.
+func (s *CredentialsSource) Secret() bool {
+	return true
+}
@@ -0,0 +1,39 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialsSource_Unset(t *testing.T) {
+	t.Setenv(CredentialsDirectoryEnv, "")
+
+	data, err := NewCredentialsSource().Load()
+	if err != nil {
+		t.Fatalf("expected no error without %s, got %v", CredentialsDirectoryEnv, err)
+	}
+	if len(data) != 0 {
+		t.Errorf("expected empty map, got %v", data)
+	}
+}
+
+func TestCredentialsSource_Load(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.pass"), []byte("hunter2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(CredentialsDirectoryEnv, dir)
+
+	src := NewCredentialsSource()
+	data, err := src.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if db, _ := data["db"].(map[string]any); db["pass"] != "hunter2" {
+		t.Errorf("expected db.pass to be hunter2, got %v", data)
+	}
+	if !src.Secret() {
+		t.Error("expected credentials source to report Secret() == true")
+	}
+}
@@ -0,0 +1,114 @@
+package sources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// k8sDataLink is the symlink Kubernetes swaps atomically when a mounted
+// ConfigMap or Secret is updated.
+const k8sDataLink = "..data"
+
+// DirSource loads configuration from a directory where every regular file is
+// a key and its contents are the value. This is the layout Kubernetes uses
+// when mounting ConfigMaps and Secrets as volumes.
+type DirSource struct {
+	path      string
+	delimiter string
+}
+
+// NewDirSource creates a new DirSource for the given directory.
+// File names are split on "." to build nested keys; use WithDelimiter to change it.
+func NewDirSource(path string) *DirSource {
+	return &DirSource{path: path, delimiter: "."}
+}
+
+// String describes the source for error messages.
+func (d *DirSource) String() string {
+	return "directory " + d.path
+}
+
+// WithDelimiter sets the separator used to split file names into nested keys,
+// e.g. "__" maps the file `db__host` to `db.host`.
+func (d *DirSource) WithDelimiter(delimiter string) *DirSource {
+	d.delimiter = delimiter
+	return d
+}
+
+// Load reads every file in the directory and returns configuration as a nested map.
+// Entries starting with ".." (the Kubernetes atomic-update bookkeeping),
+// subdirectories and dangling symlinks, left for removed keys while kubelet
+// swaps `..data`, are skipped. A single trailing newline is trimmed from values.
+func (d *DirSource) Load() (map[string]any, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %s: %w", d.path, err)
+	}
+
+	out := make(map[string]any)
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "..") {
+			continue
+		}
+		full := filepath.Join(d.path, name)
+		// Stat follows symlinks, so keys linked into ..data resolve to their files.
+		info, err := os.Stat(full)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", full, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(full)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading file %s: %w", full, err)
+		}
+		val := strings.TrimSuffix(string(data), "\n")
+
+		keys := []string{name}
+		if d.delimiter != "" {
+			keys = strings.Split(name, d.delimiter)
+		}
+		setNestedValue(out, keys, val)
+	}
+	return out, nil
+}
+
+// Revision returns a token that changes whenever the directory contents are
+// replaced. For Kubernetes mounts it is the target of the `..data` symlink,
+// which is swapped atomically on update; for plain directories it is a
+// fingerprint of file names, sizes and modification times. Hot-reload
+// watchers can poll Revision and reload when it differs from the last value.
+func (d *DirSource) Revision() (string, error) {
+	if target, err := os.Readlink(filepath.Join(d.path, k8sDataLink)); err == nil {
+		return target, nil
+	}
+
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return "", fmt.Errorf("reading directory %s: %w", d.path, err)
+	}
+	h := sha256.New()
+	for _, entry := range entries {
+		name := entry.Name()
+		info, err := os.Stat(filepath.Join(d.path, name))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s:%d:%d\n", name, info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
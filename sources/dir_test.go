@@ -0,0 +1,135 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeK8sMount lays out dir the way kubelet does: files live in a timestamped
+// directory, `..data` points at it and every key is a symlink through `..data`.
+func writeK8sMount(t *testing.T, dir, version string, files map[string]string) {
+	t.Helper()
+	versionDir := filepath.Join(dir, version)
+	if err := os.Mkdir(versionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(versionDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	if err := os.Symlink(version, tmpLink); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmpLink, filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+	for name := range files {
+		link := filepath.Join(dir, name)
+		if _, err := os.Lstat(link); err == nil {
+			continue
+		}
+		if err := os.Symlink(filepath.Join("..data", name), link); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirSource_LoadKubernetesMount(t *testing.T) {
+	dir := t.TempDir()
+	writeK8sMount(t, dir, "..2024_01_01_00_00_00.1", map[string]string{
+		"db.host": "localhost\n",
+		"db.port": "5432",
+		"port":    "3000",
+	})
+
+	data, err := NewDirSource(dir).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	db, ok := data["db"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected db to be map[string]any, got %T", data["db"])
+	}
+	if db["host"] != "localhost" {
+		t.Errorf("expected db.host to be localhost, got %q", db["host"])
+	}
+	if db["port"] != "5432" {
+		t.Errorf("expected db.port to be 5432, got %v", db["port"])
+	}
+	if data["port"] != "3000" {
+		t.Errorf("expected port to be 3000, got %v", data["port"])
+	}
+	for key := range data {
+		if key == "" || key[0] == '.' {
+			t.Errorf("unexpected hidden key %q in %v", key, data)
+		}
+	}
+}
+
+func TestDirSource_Delimiter(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db__host"), []byte("h"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := NewDirSource(dir).WithDelimiter("__").Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if db, _ := data["db"].(map[string]any); db["host"] != "h" {
+		t.Errorf("expected db.host to be h, got %v", data)
+	}
+}
+
+func TestDirSource_RevisionChangesOnSwap(t *testing.T) {
+	dir := t.TempDir()
+	writeK8sMount(t, dir, "..v1", map[string]string{"key": "one"})
+
+	src := NewDirSource(dir)
+	rev1, err := src.Revision()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeK8sMount(t, dir, "..v2", map[string]string{"key": "two"})
+	rev2, err := src.Revision()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev1 == rev2 {
+		t.Errorf("expected revision to change after ..data swap, got %q twice", rev1)
+	}
+
+	data, err := src.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["key"] != "two" {
+		t.Errorf("expected key to be two after swap, got %v", data["key"])
+	}
+}
+
+func TestDirSource_KeyDroppedOnSwap(t *testing.T) {
+	dir := t.TempDir()
+	writeK8sMount(t, dir, "..v1", map[string]string{"key": "one", "old": "gone"})
+	// v2 drops "old"; until kubelet removes its symlink it dangles.
+	writeK8sMount(t, dir, "..v2", map[string]string{"key": "two"})
+	if _, err := os.Stat(filepath.Join(dir, "old")); !os.IsNotExist(err) {
+		t.Fatalf("expected old to be a dangling symlink, got %v", err)
+	}
+
+	data, err := NewDirSource(dir).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if data["key"] != "two" {
+		t.Errorf("expected key to be two after swap, got %v", data["key"])
+	}
+	if _, ok := data["old"]; ok {
+		t.Errorf("expected dropped key to be skipped, got %v", data)
+	}
+}
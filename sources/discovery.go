@@ -0,0 +1,121 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+// SupportedExtensions lists, in order of preference, the file extensions
+// DiscoverySource looks for.
+var SupportedExtensions = []string{".yaml", ".yml", ".json"}
+
+// DiscoverySource finds a configuration file by base name across an ordered
+// list of directories, highest priority first.
+//
+// By default the first file found is loaded. With MergeAll every file found
+// is loaded and merged so that earlier directories override later ones,
+// e.g. local < user < system when using DefaultSearchPaths.
+type DiscoverySource struct {
+	name     string
+	dirs     []string
+	mergeAll bool
+	merge    internal.MergeOptions
+	used     []string
+}
+
+// NewDiscoverySource creates a DiscoverySource looking for name with any of
+// the SupportedExtensions in dirs, which are searched in order.
+func NewDiscoverySource(name string, dirs ...string) *DiscoverySource {
+	return &DiscoverySource{name: name, dirs: dirs}
+}
+
+// String describes the source for error messages.
+func (d *DiscoverySource) String() string {
+	return "discovery of " + d.name
+}
+
+// DefaultSearchPaths returns the conventional configuration directories for
+// app, highest priority first: the working directory, $XDG_CONFIG_HOME/<app>,
+// ~/.config/<app> and /etc/<app>. Duplicates and unavailable entries are omitted.
+func DefaultSearchPaths(app string) []string {
+	candidates := []string{"."}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		candidates = append(candidates, filepath.Join(xdg, app))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".config", app))
+	}
+	candidates = append(candidates, filepath.Join("/etc", app))
+
+	seen := make(map[string]bool, len(candidates))
+	out := make([]string, 0, len(candidates))
+	for _, dir := range candidates {
+		clean := filepath.Clean(dir)
+		if seen[clean] {
+			continue
+		}
+		seen[clean] = true
+		out = append(out, clean)
+	}
+	return out
+}
+
+// MergeAll loads every file found instead of only the first one.
+func (d *DiscoverySource) MergeAll() *DiscoverySource {
+	d.mergeAll = true
+	return d
+}
+
+// SetMergeOptions sets how files are merged with MergeAll. Config.Bind
+// passes the merge strategies of the target, so they apply between files too.
+func (d *DiscoverySource) SetMergeOptions(opts internal.MergeOptions) {
+	d.merge = opts
+}
+
+// Used returns the files loaded by the last call to Load, in the order they
+// were merged (lowest priority first).
+func (d *DiscoverySource) Used() []string {
+	return append([]string{}, d.used...)
+}
+
+// Load searches the directories and loads the file(s) found. It returns an
+// empty map when no file exists in any of them.
+func (d *DiscoverySource) Load() (map[string]any, error) {
+	found := d.find()
+	if !d.mergeAll && len(found) > 1 {
+		found = found[:1]
+	}
+
+	d.used = d.used[:0]
+	out := make(map[string]any)
+	// Merge from the lowest priority directory up so earlier ones win.
+	for i := len(found) - 1; i >= 0; i-- {
+		file := NewFileSource(found[i])
+		file.SetMergeOptions(d.merge)
+		data, err := file.Load()
+		if err != nil {
+			return nil, err
+		}
+		out = internal.MergeWith(out, data, d.merge)
+		d.used = append(d.used, found[i])
+	}
+	return out, nil
+}
+
+// find returns, for every directory containing the file, the path of the
+// first matching extension, in search order.
+func (d *DiscoverySource) find() []string {
+	var found []string
+	for _, dir := range d.dirs {
+		for _, ext := range SupportedExtensions {
+			path := filepath.Join(dir, d.name+ext)
+			if fileExists(path) {
+				found = append(found, path)
+				break
+			}
+		}
+	}
+	return found
+}
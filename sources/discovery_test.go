@@ -0,0 +1,74 @@
+package sources
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverySource(t *testing.T) {
+	root := t.TempDir()
+	local := filepath.Join(root, "local")
+	user := filepath.Join(root, "user")
+	system := filepath.Join(root, "system")
+	writeFiles(t, root, map[string]string{
+		"local/app.yml":   "port: 3000\n",
+		"user/app.json":   `{"port": 4000, "log": {"level": "debug"}}`,
+		"system/app.yaml": "port: 80\nlog:\n  level: info\n  format: json\n",
+	})
+
+	t.Run("first found", func(t *testing.T) {
+		src := NewDiscoverySource("app", filepath.Join(root, "missing"), user, system)
+		data, err := src.Load()
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if data["port"] != 4000 {
+			t.Errorf("expected port from user config, got %v", data["port"])
+		}
+		if want := []string{filepath.Join(user, "app.json")}; !reflect.DeepEqual(src.Used(), want) {
+			t.Errorf("expected used files %v, got %v", want, src.Used())
+		}
+	})
+
+	t.Run("merge all", func(t *testing.T) {
+		src := NewDiscoverySource("app", local, user, system).MergeAll()
+		data, err := src.Load()
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		expected := map[string]any{
+			"port": 3000,
+			"log":  map[string]any{"level": "debug", "format": "json"},
+		}
+		if !reflect.DeepEqual(data, expected) {
+			t.Errorf("Load mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+		}
+		want := []string{
+			filepath.Join(system, "app.yaml"),
+			filepath.Join(user, "app.json"),
+			filepath.Join(local, "app.yml"),
+		}
+		if !reflect.DeepEqual(src.Used(), want) {
+			t.Errorf("expected used files %v, got %v", want, src.Used())
+		}
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		src := NewDiscoverySource("nope", local, user)
+		data, err := src.Load()
+		if err != nil || len(data) != 0 || len(src.Used()) != 0 {
+			t.Errorf("expected empty result, got %v, %v, %v", data, src.Used(), err)
+		}
+	})
+}
+
+func TestDefaultSearchPaths(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	t.Setenv("HOME", "/home/u")
+
+	want := []string{".", "/xdg/app", "/home/u/.config/app", "/etc/app"}
+	if got := DefaultSearchPaths("app"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
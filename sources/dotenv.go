@@ -17,6 +17,26 @@ func NewDotEnvSource(path string) *DotEnvSource {
     return &DotEnvSource{path: path}
 }
 
+// String describes the source for error messages.
+func (d *DotEnvSource) String() string {
+    return ".env file " + d.path
+}
+
+// Path returns the path of the .env file.
+func (d *DotEnvSource) Path() string {
+    return d.path
+}
+
+// ProfileOverlay returns a DotEnvSource for `<path>.<profile>`, e.g.
+// .env.production for .env, if that file exists.
+func (d *DotEnvSource) ProfileOverlay(profile string) (Source, bool) {
+    overlay := d.path + "." + profile
+    if !fileExists(overlay) {
+        return nil, false
+    }
+    return NewDotEnvSource(overlay), true
+}
+
 // Load reads the .env file and returns configuration as a nested map.
 // Keys are normalized like EnvSource: underscores become dots and keys are lowercased.
 func (d *DotEnvSource) Load() (map[string]any, error) {
@@ -18,6 +18,11 @@ func NewEnvSource(prefix string) *EnvSource {
 	}
 }
 
+// String describes the source for error messages.
+func (e *EnvSource) String() string {
+	return "environment " + e.prefix + "*"
+}
+
 // Load loads configuration values from environment variables.
 func (e *EnvSource) Load() (map[string]any, error) {
     out := make(map[string]any)
@@ -3,13 +3,20 @@ package sources
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/shkmv/goconfig/internal"
 	"gopkg.in/yaml.v3"
 )
 
 // FileSource represents a source that loads configuration from a file.
+// Files may pull in other files with a top-level `include` list or the
+// `!include` tag, see WithIncludeRoot.
 type FileSource struct {
-	path string
+	path        string
+	includeRoot string
+	merge       internal.MergeOptions
 }
 
 // NewFileSource creates a new FileSource instance.
@@ -17,18 +24,71 @@ func NewFileSource(path string) *FileSource {
 	return &FileSource{path: path}
 }
 
-// Load loads the configuration from the file.
+// String describes the source for error messages.
+func (f *FileSource) String() string {
+	return "file " + f.path
+}
+
+// WithIncludeRoot sets the directory included files must reside in.
+// It defaults to the directory of the file itself.
+func (f *FileSource) WithIncludeRoot(dir string) *FileSource {
+	f.includeRoot = dir
+	return f
+}
+
+// SetMergeOptions sets how included files are merged. Config.Bind passes
+// the merge strategies of the target, so they apply between includes too.
+func (f *FileSource) SetMergeOptions(opts internal.MergeOptions) {
+	f.merge = opts
+}
+
+// Path returns the path of the file.
+func (f *FileSource) Path() string {
+	return f.path
+}
+
+// ProfileOverlay returns a FileSource for `<name>.<profile>.<ext>` next to the
+// file, e.g. config.production.yaml for config.yaml, if that file exists.
+func (f *FileSource) ProfileOverlay(profile string) (Source, bool) {
+	ext := filepath.Ext(f.path)
+	overlay := strings.TrimSuffix(f.path, ext) + "." + profile + ext
+	if !fileExists(overlay) {
+		return nil, false
+	}
+	return &FileSource{path: overlay, includeRoot: f.includeRoot, merge: f.merge}, true
+}
+
+// Load loads the configuration from the file, resolving includes.
 func (f *FileSource) Load() (map[string]any, error) {
-	data, err := os.ReadFile(f.path)
-	if err != nil {
-		return nil, fmt.Errorf("reading file %s: %w", f.path, err)
+	root := f.includeRoot
+	if root == "" {
+		root = filepath.Dir(f.path)
 	}
+	return newIncluder(root, f.merge).load(f.path, nil)
+}
 
+// decodeFile parses the contents of the configuration file at path.
+func decodeFile(path string, data []byte) (map[string]any, error) {
 	var out map[string]any
 	if err := yaml.Unmarshal(data, &out); err != nil {
-		return nil, fmt.Errorf("unmarshaling YAML from %s: %w", f.path, err)
+		return nil, fmt.Errorf("unmarshaling YAML from %s: %w", path, err)
 	}
 
 	// TODO: validate
 	return out, nil
 }
+
+// readFile reads the configuration file at path.
+func readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
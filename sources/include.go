@@ -0,0 +1,187 @@
+package sources
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shkmv/goconfig/internal"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// includeKey is the top-level key listing files to include.
+	includeKey = "include"
+	// includeTag replaces a single value with the contents of another file.
+	includeTag = "!include"
+)
+
+// includer loads a configuration file and everything it includes, keeping
+// every included file inside root. Included files are merged with merge.
+type includer struct {
+	root  string
+	merge internal.MergeOptions
+}
+
+func newIncluder(root string, merge internal.MergeOptions) *includer {
+	return &includer{root: canonicalPath(root), merge: merge}
+}
+
+// load reads path and resolves its includes. stack holds the canonical paths
+// of the files currently being included, for cycle detection.
+func (in *includer) load(path string, stack []string) (map[string]any, error) {
+	canonical := canonicalPath(path)
+	for i, p := range stack {
+		if p == canonical {
+			chain := append(append([]string{}, stack[i:]...), canonical)
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	stack = append(stack, canonical)
+
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling YAML from %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		return nil, nil
+	}
+	if err := in.expandTags(&doc, nil, path, stack); err != nil {
+		return nil, err
+	}
+
+	var own map[string]any
+	if err := doc.Decode(&own); err != nil {
+		return nil, fmt.Errorf("unmarshaling YAML from %s: %w", path, err)
+	}
+
+	patterns, err := includePatterns(own[includeKey])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if patterns == nil {
+		return own, nil
+	}
+	delete(own, includeKey)
+
+	// Included files are merged in order beneath the including file's own keys.
+	out := make(map[string]any)
+	for _, pattern := range patterns {
+		included, err := in.loadPattern(pattern, nil, path, stack)
+		if err != nil {
+			return nil, err
+		}
+		out = internal.MergeWith(out, included, in.merge)
+	}
+	return internal.MergeWith(out, own, in.merge), nil
+}
+
+// expandTags replaces every `!include <pattern>` node below n, found at the
+// dotted key path keys, with the contents of the referenced files.
+func (in *includer) expandTags(n *yaml.Node, keys []string, path string, stack []string) error {
+	if n.Kind == yaml.ScalarNode && n.Tag == includeTag {
+		included, err := in.loadPattern(n.Value, keys, path, stack)
+		if err != nil {
+			return err
+		}
+		var replacement yaml.Node
+		if err := replacement.Encode(included); err != nil {
+			return fmt.Errorf("%s: encoding included %s: %w", path, n.Value, err)
+		}
+		*n = replacement
+		return nil
+	}
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			sub := append(append([]string{}, keys...), n.Content[i].Value)
+			if err := in.expandTags(n.Content[i+1], sub, path, stack); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, child := range n.Content {
+		if err := in.expandTags(child, keys, path, stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPattern loads and merges, in lexical order, the files matching pattern
+// relative to the including file, to be placed at the dotted key path keys.
+// Patterns without glob metacharacters must match an existing file; globs may
+// match nothing.
+func (in *includer) loadPattern(pattern string, keys []string, from string, stack []string) (map[string]any, error) {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(filepath.Dir(from), pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid include pattern %s: %w", from, pattern, err)
+	}
+	if len(matches) == 0 && !strings.ContainsAny(pattern, "*?[") {
+		matches = []string{pattern}
+	}
+
+	merge := in.merge
+	merge.Prefix = strings.Join(keys, ".")
+	out := make(map[string]any)
+	for _, match := range matches {
+		if err := in.checkRoot(match); err != nil {
+			return nil, fmt.Errorf("%s: %w", from, err)
+		}
+		included, err := in.load(match, stack)
+		if err != nil {
+			return nil, fmt.Errorf("including %s from %s: %w", match, from, err)
+		}
+		out = internal.MergeWith(out, included, merge)
+	}
+	return out, nil
+}
+
+// checkRoot rejects paths that resolve outside the include root.
+func (in *includer) checkRoot(path string) error {
+	rel, err := filepath.Rel(in.root, canonicalPath(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("include %s escapes allowed root %s", path, in.root)
+	}
+	return nil
+}
+
+// includePatterns normalizes the value of the include key to a list of patterns.
+func includePatterns(v any) ([]string, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{val}, nil
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("include entries must be strings, got %T", item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("include must be a string or list of strings, got %T", v)
+	}
+}
+
+// canonicalPath returns an absolute path with symlinks resolved when possible.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
@@ -0,0 +1,129 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileSource_Includes(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.yaml": `
+include: [common.yaml, db/*.yaml]
+port: 3000
+log:
+  level: debug
+tls: !include tls.yaml
+`,
+		"common.yaml": `
+port: 80
+log:
+  level: info
+  format: json
+`,
+		"db/a.yaml": "db:\n  host: a\n  port: 5432\n",
+		"db/b.yaml": "db:\n  host: b\n",
+		"tls.yaml":  "cert: /etc/cert.pem\n",
+	})
+
+	data, err := NewFileSource(filepath.Join(dir, "config.yaml")).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"port": 3000,
+		"log":  map[string]any{"level": "debug", "format": "json"},
+		"db":   map[string]any{"host": "b", "port": 5432},
+		"tls":  map[string]any{"cert": "/etc/cert.pem"},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Load mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+}
+
+func TestFileSource_IncludeMergeOptions(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.yaml":   "include: [a.yaml, b.yaml]\nserver: !include server/*.yaml\n",
+		"a.yaml":        "plugins: [auth]\n",
+		"b.yaml":        "plugins: [metrics]\n",
+		"server/a.yaml": "hosts: [a]\n",
+		"server/b.yaml": "hosts: [b]\n",
+	})
+
+	src := NewFileSource(filepath.Join(dir, "config.yaml"))
+	src.SetMergeOptions(internal.MergeOptions{Strategies: internal.Strategies{
+		"plugins":      internal.StrategyAppend,
+		"server.hosts": internal.StrategyAppend,
+	}})
+	data, err := src.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"plugins": []any{"auth", "metrics"},
+		"server":  map[string]any{"hosts": []any{"a", "b"}},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Load mismatch.\nGot:  %#v\nWant: %#v", data, expected)
+	}
+}
+
+func TestFileSource_IncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.yaml": "include: b.yaml\n",
+		"b.yaml": "include: a.yaml\n",
+	})
+
+	_, err := NewFileSource(filepath.Join(dir, "a.yaml")).Load()
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("expected include cycle error, got %v", err)
+	}
+}
+
+func TestFileSource_IncludeSandbox(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"outside.yaml":     "secret: 1\n",
+		"app/config.yaml":  "include: ../outside.yaml\n",
+		"app/missing.yaml": "include: nothere.yaml\n",
+	})
+
+	_, err := NewFileSource(filepath.Join(dir, "app", "config.yaml")).Load()
+	if err == nil || !strings.Contains(err.Error(), "escapes allowed root") {
+		t.Errorf("expected sandbox error, got %v", err)
+	}
+
+	data, err := NewFileSource(filepath.Join(dir, "app", "config.yaml")).WithIncludeRoot(dir).Load()
+	if err != nil {
+		t.Fatalf("expected include within widened root to succeed, got %v", err)
+	}
+	if data["secret"] != 1 {
+		t.Errorf("expected secret to be 1, got %v", data)
+	}
+
+	if _, err := NewFileSource(filepath.Join(dir, "app", "missing.yaml")).Load(); err == nil {
+		t.Error("expected error for missing include, got nil")
+	}
+}
@@ -8,3 +8,21 @@ type Source interface {
 	// It returns an error if the loading process fails.
 	Load() (map[string]any, error)
 }
+
+// SecretSource is implemented by sources whose values are sensitive as a whole.
+// Every key loaded from a source whose Secret method returns true is masked by
+// the MaskedMap and MaskedJSON methods of the Config that bound it, even
+// without a `secret:"true"` tag.
+type SecretSource interface {
+	Source
+	Secret() bool
+}
+
+// ProfileSource is implemented by sources that can be layered with a
+// profile-specific overlay, such as config.production.yaml for config.yaml.
+type ProfileSource interface {
+	Source
+	// ProfileOverlay returns the overlay source for profile and true, or
+	// false if no overlay exists.
+	ProfileOverlay(profile string) (Source, bool)
+}
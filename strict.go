@@ -0,0 +1,82 @@
+package goconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shkmv/goconfig/internal"
+)
+
+// Strict makes Bind fail when sources contain keys that no field of the
+// target refers to, listing every unknown key with the source it came from.
+func (c *Config) Strict() *Config {
+	c.strict = true
+	return c
+}
+
+// OnUnknownKey enables strict key checking but reports unknown keys to fn
+// instead of failing Bind.
+func (c *Config) OnUnknownKey(fn func(key, source string)) *Config {
+	c.strict = true
+	c.onUnknown = fn
+	return c
+}
+
+// Lenient excludes the most recently added source from strict key checking,
+// e.g. an EnvSource with a broad prefix that naturally carries extra keys.
+func (c *Config) Lenient() *Config {
+	if len(c.sources) == 0 {
+		return c
+	}
+	if c.lenient == nil {
+		c.lenient = make(map[int]bool)
+	}
+	c.lenient[len(c.sources)-1] = true
+	return c
+}
+
+// checkUnknownKeys reports merged keys unknown to target unless only lenient
+// sources set them. origins maps the keys set by non-lenient sources to their
+// source and setBy the keys set by any source.
+func (c *Config) checkUnknownKeys(merged map[string]any, origins, setBy map[string]string, target any) error {
+	if !c.strict {
+		return nil
+	}
+
+	fold := c.keyCase != KeyCaseStrict
+	var problems []string
+	for _, key := range internal.UnknownKeys(merged, reflect.TypeOf(target), c.bindOptions()) {
+		source, ok := lookupSource(origins, key, fold)
+		if !ok {
+			if _, lenient := lookupSource(setBy, key, fold); lenient {
+				continue
+			}
+			source = "unknown source"
+		}
+		if c.onUnknown != nil {
+			c.onUnknown(key, source)
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s (from %s)", key, source))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("unknown configuration keys: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// lookupSource returns the source recorded for key. Sources may spell keys
+// differently from the merged result, so with fold set they are compared
+// case-insensitively.
+func lookupSource(sources map[string]string, key string, fold bool) (string, bool) {
+	if src, ok := sources[key]; ok || !fold {
+		return src, ok
+	}
+	for k, src := range sources {
+		if strings.EqualFold(k, key) {
+			return src, true
+		}
+	}
+	return "", false
+}
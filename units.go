@@ -0,0 +1,162 @@
+package goconfig
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes that binds from human readable values such as
+// "512MB" or "10MiB". SI suffixes (KB, MB, GB, ...) are powers of 1000 and
+// IEC suffixes (KiB, MiB, GiB, ...) powers of 1024. Suffixes are case
+// insensitive, fractions like "1.5GiB" are allowed and a plain number is a
+// count of bytes.
+type ByteSize uint64
+
+// Byte size units.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+// byteUnits lists the suffixes from largest to smallest, so String picks the
+// largest unit that represents a size exactly.
+var byteUnits = []struct {
+	suffix string
+	size   ByteSize
+}{
+	{"EiB", EiB}, {"EB", EB},
+	{"PiB", PiB}, {"PB", PB},
+	{"TiB", TiB}, {"TB", TB},
+	{"GiB", GiB}, {"GB", GB},
+	{"MiB", MiB}, {"MB", MB},
+	{"KiB", KiB}, {"KB", KB},
+}
+
+// ParseByteSize parses a size such as "10MiB", "512 MB" or "4096".
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	num := strings.TrimRightFunc(s, func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	})
+	unit := Byte
+	if suffix := s[len(num):]; suffix != "" {
+		var ok bool
+		if unit, ok = byteUnit(suffix); !ok {
+			return 0, fmt.Errorf("unknown byte size unit %q in %q", suffix, s)
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	size := n * float64(unit)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size %q overflows", s)
+	}
+	return ByteSize(math.Round(size)), nil
+}
+
+// byteUnit returns the size of a suffix. "B" stands for bytes and "K", "M",
+// ... are accepted as short forms of the SI suffixes.
+func byteUnit(suffix string) (ByteSize, bool) {
+	switch strings.ToLower(suffix) {
+	case "b":
+		return Byte, true
+	}
+	for _, u := range byteUnits {
+		if strings.EqualFold(suffix, u.suffix) || strings.EqualFold(suffix+"b", u.suffix) {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
+
+// String formats the size with the largest unit that represents it exactly,
+// e.g. "10MiB" or "512MB", falling back to bytes such as "1500B".
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if b >= u.size && b%u.size == 0 {
+			return strconv.FormatUint(uint64(b/u.size), 10) + u.suffix
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// MarshalText implements encoding.TextMarshaler, so MaskedJSON shows sizes in
+// human form.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// Percent is a ratio that binds from percent strings such as "5%" or
+// "12.5%". It holds the fraction, so "5%" is 0.05; values without a percent
+// sign, like 0.05 in YAML, are taken as the fraction itself.
+type Percent float64
+
+// ParsePercent parses "5%", "5 %" or a plain fraction like "0.05".
+func ParsePercent(s string) (Percent, error) {
+	s = strings.TrimSpace(s)
+	num, percent := strings.CutSuffix(s, "%")
+	f, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
+	if percent {
+		f /= 100
+	}
+	return Percent(f), nil
+}
+
+// Of returns the percentage of v, e.g. Percent(0.05).Of(200) is 10.
+func (p Percent) Of(v float64) float64 {
+	return float64(p) * v
+}
+
+// String formats the percentage with a percent sign, e.g. "5%".
+func (p Percent) String() string {
+	// Round away float noise such as 7.000000000000001.
+	v := math.Round(float64(p)*100*1e9) / 1e9
+	return strconv.FormatFloat(v, 'f', -1, 64) + "%"
+}
+
+// MarshalText implements encoding.TextMarshaler, so MaskedJSON shows
+// percentages in human form.
+func (p Percent) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Percent) UnmarshalText(text []byte) error {
+	v, err := ParsePercent(string(text))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
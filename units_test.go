@@ -0,0 +1,120 @@
+package goconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ByteSize
+		str  string
+	}{
+		{"4096", 4096, "4KiB"},
+		{"1500B", 1500, "1500B"},
+		{"10MiB", 10 * MiB, "10MiB"},
+		{"512MB", 512 * MB, "512MB"},
+		{"512 mb", 512 * MB, "512MB"},
+		{"1.5GiB", 1536 * MiB, "1536MiB"},
+		{"2G", 2 * GB, "2GB"},
+		{"1k", KB, "1KB"},
+		{"0", 0, "0B"},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if err != nil {
+			t.Errorf("ParseByteSize(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("ByteSize(%d).String() = %q, want %q", got, got.String(), tt.str)
+		}
+	}
+
+	for _, in := range []string{"", "MB", "-1KB", "10XB", "20EiB"} {
+		if _, err := ParseByteSize(in); err == nil {
+			t.Errorf("ParseByteSize(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Percent
+		str  string
+	}{
+		{"5%", 0.05, "5%"},
+		{"12.5 %", 0.125, "12.5%"},
+		{"7%", 0.07, "7%"},
+		{"0.25", 0.25, "25%"},
+		{"150%", 1.5, "150%"},
+	}
+	for _, tt := range tests {
+		got, err := ParsePercent(tt.in)
+		if err != nil {
+			t.Errorf("ParsePercent(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePercent(%q) = %v, want %v", tt.in, float64(got), float64(tt.want))
+		}
+		if got.String() != tt.str {
+			t.Errorf("Percent(%v).String() = %q, want %q", float64(got), got.String(), tt.str)
+		}
+	}
+	if _, err := ParsePercent("five%"); err == nil {
+		t.Error("Expected error for invalid percentage, got nil")
+	}
+}
+
+func TestUnitsBindFromAllSources(t *testing.T) {
+	type Limits struct {
+		MaxBody    ByteSize `config:"max_body"`
+		Cache      ByteSize `config:"cache"`
+		Upload     ByteSize `config:"upload"`
+		Buffer     ByteSize `config:"buffer"`
+		SampleRate Percent  `config:"sample_rate"`
+		Errors     Percent  `config:"errors"`
+	}
+	type AppConfig struct {
+		Limits Limits `config:"limits"`
+	}
+
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "config.yaml")
+	yaml := "limits:\n  max_body: 10MiB\n  cache: 512MB\n  buffer: 4096\n  sample_rate: 5%\n"
+	if err := os.WriteFile(yamlPath, []byte(yaml), 0644); err != nil {
+		t.Fatalf("Failed to write YAML file: %v", err)
+	}
+	envPath := filepath.Join(tempDir, ".env")
+	if err := os.WriteFile(envPath, []byte("LIMITS_UPLOAD=1.5GiB\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .env file: %v", err)
+	}
+	t.Setenv("UNITSAPP_LIMITS_ERRORS", "0.5%")
+
+	cfg, err := Load[AppConfig](WithFile(yamlPath), WithDotEnv(envPath), WithEnv("UNITSAPP_"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	want := Limits{MaxBody: 10 * MiB, Cache: 512 * MB, Upload: 1536 * MiB, Buffer: 4096, SampleRate: 0.05, Errors: 0.005}
+	if cfg.Limits != want {
+		t.Errorf("Unexpected limits: %+v", cfg.Limits)
+	}
+
+	masked, err := MaskedJSON(&cfg)
+	if err != nil {
+		t.Fatalf("MaskedJSON failed: %v", err)
+	}
+	for _, s := range []string{`"max_body":"10MiB"`, `"cache":"512MB"`, `"upload":"1536MiB"`, `"sample_rate":"5%"`, `"errors":"0.5%"`} {
+		if !strings.Contains(masked, s) {
+			t.Errorf("Expected %s in masked JSON, got %s", s, masked)
+		}
+	}
+}